@@ -0,0 +1,210 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// MaxParams is the maximum number of bind parameters in a statement, by engine.
+// SQLite was limited to 999 until version 3.32.0, which increased it to 32766.
+var MaxParams = map[Engine]int{
+	MySQL:    65535,
+	Postgres: 65535,
+	SQLite:   999,
+}
+
+// maxAllowedPacket is the default value of "max_allowed_packet" in MySQL 5.7.
+const maxAllowedPacket = 4 << 20
+
+// BatchOptions represents the options to insert several rows.
+type BatchOptions struct {
+	// MaxParams is the maximum number of bind parameters by statement.
+	// If it is zero, it is used the value in MaxParams for the engine.
+	MaxParams int
+
+	// MaxBytes is the maximum size of the values sent by statement in MySQL,
+	// which has to be under its variable "max_allowed_packet".
+	// If it is zero, it is used 4 MiB.
+	MaxBytes int
+
+	// CopyIn returns the statement to copy data from the standard input in
+	// Postgres, like the function CopyIn of the driver "github.com/lib/pq".
+	// If it is set, the rows are inserted through "COPY FROM STDIN".
+	CopyIn func(table string, columns ...string) string
+}
+
+// txBeginner is the interface that wraps the method to start a transaction.
+// It is satisfied by *sql.DB and *sql.Conn.
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// BatchInsert inserts the rows in the table within a transaction, using
+// statements with multiple rows which are split according to the limits of
+// the engine. Every row has to have a value by column.
+// The constraint violations are returned like *ConstraintError.
+//
+// If q is a transaction, the rows are inserted in it; else, q has to be able
+// to start a transaction.
+// It is to be called from the Go code generated.
+func BatchInsert(ctx context.Context, q Querier, eng Engine, opts *BatchOptions,
+	table string, columns []string, rows [][]interface{}) error {
+
+	if len(rows) == 0 {
+		return nil
+	}
+	if opts == nil {
+		opts = new(BatchOptions)
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return fmt.Errorf("table %q: row %d: have %d values, want %d",
+				table, i, len(row), len(columns))
+		}
+	}
+
+	insert := func(tx *sql.Tx) error {
+		if opts.CopyIn != nil && eng == Postgres {
+			return copyIn(ctx, tx, opts.CopyIn(table, columns...), rows)
+		}
+		return batchInsert(ctx, tx, eng, opts, table, columns, rows)
+	}
+
+	return withTx(ctx, q, table, insert)
+}
+
+// withTx runs fn within the transaction q or, else, within a new transaction
+// started through q, which is committed if fn returns nil.
+func withTx(ctx context.Context, q Querier, table string, fn func(tx *sql.Tx) error) error {
+	switch t := q.(type) {
+	case *sql.Tx:
+		return fn(t)
+	case txBeginner:
+		tx, err := t.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if err = fn(tx); err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	}
+	return fmt.Errorf("table %q: %T can not start a transaction", table, q)
+}
+
+// sqlBatchInsert returns the start of the statement to insert several rows,
+// with the names quoted.
+func sqlBatchInsert(table string, columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, v := range columns {
+		quoted[i] = quoteStatementSQL(v)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES ",
+		quoteStatementSQL(table), strings.Join(quoted, ", "))
+}
+
+// batchInsert inserts the rows through statements with multiple rows.
+func batchInsert(ctx context.Context, tx *sql.Tx, eng Engine, opts *BatchOptions,
+	table string, columns []string, rows [][]interface{}) error {
+
+	insert := sqlBatchInsert(table, columns)
+	values := "(" + strings.Repeat("{P}, ", len(columns)-1) + "{P})"
+
+	for _, chunk := range splitBatch(eng, opts, rows) {
+		query := insert + strings.Repeat(values+", ", len(chunk)-1) + values
+
+		args := make([]interface{}, 0, len(chunk)*len(columns))
+		for _, row := range chunk {
+			args = append(args, row...)
+		}
+
+		if _, err := tx.ExecContext(ctx, SQLReplacer(eng, query), args...); err != nil {
+			return ClassifyError(eng, err)
+		}
+	}
+	return nil
+}
+
+// copyIn inserts the rows through the statement to copy data from the
+// standard input.
+func copyIn(ctx context.Context, tx *sql.Tx, query string, rows [][]interface{}) error {
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if _, err = stmt.ExecContext(ctx, row...); err != nil {
+			stmt.Close()
+			return err
+		}
+	}
+	if _, err = stmt.ExecContext(ctx); err != nil { // flush data
+		stmt.Close()
+		return err
+	}
+	return stmt.Close()
+}
+
+// splitBatch splits the rows to be under the limits of bind parameters and,
+// in MySQL, of bytes by statement.
+func splitBatch(eng Engine, opts *BatchOptions, rows [][]interface{}) [][][]interface{} {
+	maxParams := opts.MaxParams
+	if maxParams == 0 {
+		maxParams = MaxParams[eng]
+	}
+	maxBytes := 0
+	if eng == MySQL {
+		if maxBytes = opts.MaxBytes; maxBytes == 0 {
+			maxBytes = maxAllowedPacket
+		}
+	}
+
+	maxRows := maxParams / len(rows[0])
+	if maxRows == 0 {
+		maxRows = 1
+	}
+
+	var chunks [][][]interface{}
+	start, size := 0, 0
+
+	for i, row := range rows {
+		rowSize := 0
+		if maxBytes != 0 {
+			for _, v := range row {
+				rowSize += valueSize(v)
+			}
+		}
+
+		if i != start && (i-start == maxRows || (maxBytes != 0 && size+rowSize > maxBytes)) {
+			chunks = append(chunks, rows[start:i])
+			start, size = i, 0
+		}
+		size += rowSize
+	}
+	return append(chunks, rows[start:])
+}
+
+// valueSize returns the approximate size of a value sent to the database.
+func valueSize(v interface{}) int {
+	switch t := v.(type) {
+	case string:
+		return len(t) + 2 // quotes
+	case *string:
+		return len(*t) + 2
+	case []byte:
+		return 2*len(t) + 3 // hexadecimal
+	case *[]byte:
+		return 2*len(*t) + 3
+	}
+	return 20
+}
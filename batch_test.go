@@ -0,0 +1,70 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSplitBatch(t *testing.T) {
+	rows := make([][]interface{}, 1000)
+	for i := range rows {
+		rows[i] = []interface{}{i, "abc", 1.5}
+	}
+
+	tests := []struct {
+		eng    Engine
+		opts   BatchOptions
+		chunks int
+	}{
+		{Postgres, BatchOptions{}, 1},
+		{SQLite, BatchOptions{}, 4},                       // 333 rows by statement
+		{SQLite, BatchOptions{MaxParams: 32766}, 1},       // SQLite >= 3.32.0
+		{MySQL, BatchOptions{MaxBytes: 450}, 100},         // 10 rows of 45 bytes
+		{Postgres, BatchOptions{MaxBytes: 450}, 1},        // only for MySQL
+		{Postgres, BatchOptions{MaxParams: 2}, len(rows)}, // at least a row
+	}
+
+	for _, tt := range tests {
+		chunks := splitBatch(tt.eng, &tt.opts, rows)
+		if len(chunks) != tt.chunks {
+			t.Errorf("%s %+v: got %d chunks, want %d", tt.eng, tt.opts, len(chunks), tt.chunks)
+		}
+
+		n := 0
+		for _, c := range chunks {
+			n += len(c)
+		}
+		if n != len(rows) {
+			t.Errorf("%s %+v: got %d rows, want %d", tt.eng, tt.opts, n, len(rows))
+		}
+	}
+}
+
+func TestBatchInsert(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	columns := []string{"a", "b"}
+	rows := [][]interface{}{{1, "a"}, {2, "b"}}
+
+	if err := BatchInsert(context.Background(), db, SQLite, nil, "user", columns, rows); err != nil {
+		t.Error(err)
+	}
+
+	if got := sqlBatchInsert("user", []string{"id", "order", "group"}); got != "INSERT INTO {Q}user{Q} (id, {Q}order{Q}, {Q}group{Q}) VALUES " {
+		t.Errorf("got wrong statement: %q", got)
+	}
+
+	err := BatchInsert(context.Background(), db, SQLite, nil, "user", columns,
+		[][]interface{}{{1}})
+	if err == nil || !strings.Contains(err.Error(), "row 0") {
+		t.Errorf("expected to get an error by wrong number of values, got %v", err)
+	}
+}
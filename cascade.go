@@ -0,0 +1,143 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// A Relation represents a foreign key from the columns of a table to the
+// columns referenced in other table.
+type Relation struct {
+	Table      string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+}
+
+// DeleteCascade deletes the rows of the table whose columns have the given
+// values, after of deleting the rows which depend on them through the
+// relations, children first; all within a transaction, like in BatchInsert.
+// It returns the number of rows deleted by table.
+//
+// If dryRun is true, nothing is deleted and it is returned the number of rows
+// which would be deleted.
+//
+// The relations which reference a table already in the path, like the
+// self-referencing foreign keys, are not followed.
+// It is to be called from the Go code generated.
+func DeleteCascade(ctx context.Context, q Querier, eng Engine, rels []Relation,
+	table string, columns []string, args []interface{}, dryRun bool) (map[string]int64, error) {
+
+	if len(columns) != len(args) {
+		return nil, fmt.Errorf("table %q: have %d values, want %d", table, len(args), len(columns))
+	}
+	stmts := cascadeStatements(rels, table, columns)
+	res := make(map[string]int64, len(stmts))
+
+	err := withTx(ctx, q, table, func(tx *sql.Tx) error {
+		for _, s := range stmts {
+			stmtArgs := make([]interface{}, 0, len(args)*s.nCond)
+			for i := 0; i < s.nCond; i++ {
+				stmtArgs = append(stmtArgs, args...)
+			}
+			from := quoteStatementSQL(s.table) + " WHERE " + s.where
+
+			if dryRun {
+				var n int64
+				err := tx.QueryRowContext(ctx, SQLReplacer(eng, "SELECT COUNT(*) FROM "+from),
+					stmtArgs...).Scan(&n)
+				if err != nil {
+					return err
+				}
+				res[s.table] = n
+				continue
+			}
+
+			r, err := tx.ExecContext(ctx, SQLReplacer(eng, "DELETE FROM "+from), stmtArgs...)
+			if err != nil {
+				return ClassifyError(eng, err)
+			}
+			if res[s.table], err = r.RowsAffected(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// cascadeStmt represents the condition to select the rows of a table to delete.
+type cascadeStmt struct {
+	table string
+	where string
+	nCond int // conditions joined by OR, where every one uses all arguments
+}
+
+// cascadeStatements returns the conditions to select the rows of every table
+// which depend on the rows of the given table, children first.
+func cascadeStatements(rels []Relation, table string, columns []string) []cascadeStmt {
+	conds := make(map[string][]string)
+	path := make(map[string]bool)
+
+	var visit func(tbl, cond string)
+	visit = func(tbl, cond string) {
+		conds[tbl] = append(conds[tbl], cond)
+		path[tbl] = true
+
+		for _, r := range rels {
+			if r.RefTable != tbl || path[r.Table] {
+				continue
+			}
+			join := make([]string, len(r.Columns))
+			for i := range r.Columns {
+				join[i] = fmt.Sprintf("%s.%s = %s.%s",
+					quoteStatementSQL(tbl), quoteStatementSQL(r.RefColumns[i]),
+					quoteStatementSQL(r.Table), quoteStatementSQL(r.Columns[i]))
+			}
+			visit(r.Table, fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE %s AND %s)",
+				quoteStatementSQL(tbl), strings.Join(join, " AND "), cond))
+		}
+		delete(path, tbl)
+	}
+
+	where := make([]string, len(columns))
+	for i, v := range columns {
+		where[i] = quoteStatementSQL(table) + "." + quoteStatementSQL(v) + " = {P}"
+	}
+	visit(table, strings.Join(where, " AND "))
+
+	// Sort the tables so every one is after of the tables which depend on it.
+	var stmts []cascadeStmt
+	done := make(map[string]bool)
+
+	var sortTables func(tbl string)
+	sortTables = func(tbl string) {
+		done[tbl] = true
+		for _, r := range rels {
+			if r.RefTable == tbl && !done[r.Table] && conds[r.Table] != nil {
+				sortTables(r.Table)
+			}
+		}
+
+		c := conds[tbl]
+		where := c[0]
+		if len(c) > 1 {
+			where = "(" + strings.Join(c, ") OR (") + ")"
+		}
+		stmts = append(stmts, cascadeStmt{tbl, where, len(c)})
+	}
+	sortTables(table)
+
+	return stmts
+}
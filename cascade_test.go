@@ -0,0 +1,64 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"testing"
+)
+
+var testRelations = []Relation{
+	{"chapter", []string{"book_fk"}, "book", []string{"book_id"}},
+	{"note", []string{"chapter_fk"}, "chapter", []string{"chapter_id"}},
+	{"note", []string{"book_fk"}, "book", []string{"book_id"}},
+	{"book", []string{"parent"}, "book", []string{"book_id"}}, // self-referencing
+}
+
+func TestCascadeStatements(t *testing.T) {
+	stmts := cascadeStatements(testRelations, "book", []string{"book_id"})
+
+	want := []cascadeStmt{
+		{"note", "(EXISTS (SELECT 1 FROM chapter WHERE chapter.chapter_id = note.chapter_fk AND " +
+			"EXISTS (SELECT 1 FROM book WHERE book.book_id = chapter.book_fk AND book.book_id = {P}))) OR " +
+			"(EXISTS (SELECT 1 FROM book WHERE book.book_id = note.book_fk AND book.book_id = {P}))", 2},
+		{"chapter", "EXISTS (SELECT 1 FROM book WHERE book.book_id = chapter.book_fk AND book.book_id = {P})", 1},
+		{"book", "book.book_id = {P}", 1},
+	}
+
+	if len(stmts) != len(want) {
+		t.Fatalf("got %d statements, want %d: %v", len(stmts), len(want), stmts)
+	}
+	for i := range want {
+		if stmts[i] != want[i] {
+			t.Errorf("statement %d:\ngot  %v\nwant %v", i, stmts[i], want[i])
+		}
+	}
+
+	if stmts = cascadeStatements(testRelations, "note", []string{"id"}); len(stmts) != 1 {
+		t.Errorf("expected to get only the table without dependent rows, got %v", stmts)
+	}
+}
+
+func TestDeleteCascade(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	res, err := DeleteCascade(context.Background(), db, Postgres, testRelations,
+		"book", []string{"book_id"}, []interface{}{1}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 3 || res["note"] != 1 {
+		t.Errorf("got deleted rows %v", res)
+	}
+
+	_, err = DeleteCascade(context.Background(), db, Postgres, testRelations,
+		"book", []string{"book_id"}, nil, false)
+	if err == nil {
+		t.Error("expected to get an error by wrong number of values")
+	}
+}
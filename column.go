@@ -8,7 +8,7 @@ package modsql
 
 import (
 	"fmt"
-	"log"
+	"strings"
 	"time"
 
 	//"github.com/kless/validate"
@@ -20,6 +20,7 @@ const (
 	primaryKey constraintType = 1 << iota
 	foreignKey
 	uniqueCons
+	checkCons
 )
 
 type indexType int
@@ -44,8 +45,25 @@ type column struct {
 	fkTable  string
 	fkColumn string
 
+	size   int // maximum length of a string
+	prefix int // MySQL: length of the prefix in the indexes
+
+	comment string
+
 	defaultValue interface{}
+	defaultExpr  string            // SQL expression, like a template
+	defaultExprs map[Engine]string // SQL expression for a given engine
 	//validators   validationType
+
+	enum  *nativeEnum // type of the column
+	mixin *mixin      // embedded mixin which the column comes from
+
+	auto bool // value set by the database, out of the insertions
+	null bool // it can be NULL
+
+	// For the field in the Go type
+	goName    string
+	omitEmpty bool
 }
 
 // Column defines a new column.
@@ -53,6 +71,9 @@ func Column(name string, t sqlType) *column {
 	c := new(column)
 	c.Name = name
 	c.type_ = t
+	if t == Char {
+		c.size = 1
+	}
 	return c
 }
 
@@ -111,15 +132,53 @@ func (c *column) Index(unique bool) *column {
 	return c
 }
 
+// Size sets the maximum length, in characters, of a column of type String,
+// which is created with the type VARCHAR; or the length of a column of type
+// Char, which is 1 by default. The Go type generated has the method Validate
+// to check the length.
+//
+// The values of type Char shorter than the length are padded with blanks by
+// Postgres, so the trailing blanks are removed at scanning them.
+func (c *column) Size(n int) *column {
+	if c.type_ != String && c.type_ != Char {
+		columnsErr = append(columnsErr,
+			fmt.Sprintf("\n column %q only can have set a size with type String or Char", c.Name))
+	}
+	if n <= 0 {
+		columnsErr = append(columnsErr, fmt.Sprintf("\n column %q with size %d", c.Name, n))
+	}
+	c.size = n
+	return c
+}
+
+// IndexPrefix sets the length of the prefix of the column to use in the
+// indexes in MySQL, which requires it for the columns of type TEXT and BLOB.
+// So, a column of type String or Binary without size is created with those
+// types, instead of being limited to 255 characters, when it is only used in
+// indexes.
+func (c *column) IndexPrefix(n int) *column {
+	if c.type_ != String && c.type_ != Binary {
+		columnsErr = append(columnsErr,
+			fmt.Sprintf("\n column %q only can have set an index prefix with type String or Binary", c.Name))
+	}
+	c.prefix = n
+	return c
+}
+
 // Default sets a value by default.
+// In MySQL, the columns of type String or Binary with a value by default are
+// created with a type of variable length (VARCHAR, VARBINARY), since the types
+// TEXT and BLOB cannot have it.
 func (c *column) Default(v interface{}) *column {
-	switch c.type_ {
-	// MySQL: BLOB and TEXT columns cannot be assigned a default value.
-	case String, Binary:
-		log.Fatalf("type of column in %q can not have a default value", c.Name)
+	if c.hasDefaultExpr() {
+		c.addErrorDefault()
 	}
 	c.defaultValue = v
 
+	// The SQL files are parsed like templates.
+	if s, ok := v.(string); ok && strings.Contains(s, "{{") {
+		columnsErr = append(columnsErr, fmt.Sprintf("\n column %q with \"{{\" in value", c.Name))
+	}
 	if ok := c.checkDefValue(); !ok {
 		columnsErr = append(columnsErr, fmt.Sprintf("\n column %q with type %T",
 			c.Name, c.defaultValue),
@@ -128,6 +187,37 @@ func (c *column) Default(v interface{}) *column {
 	return c
 }
 
+// DefaultExpr sets a SQL expression by default, like "{{.Now}}", which is the
+// current time in UTC for every engine; "CURRENT_TIMESTAMP" is the local time
+// in Postgres.
+// If some engine is given, the expression is only used in them; else, it is
+// used in the engines without an expression of their own. So, an expression
+// can be given for every engine:
+//
+//	Column("uuid", String).
+//		DefaultExpr("gen_random_uuid()", Postgres).
+//		DefaultExpr("(uuid())", MySQL)
+//
+// Some engines require the expressions which are not constants to be enclosed
+// in parentheses.
+func (c *column) DefaultExpr(expr string, eng ...Engine) *column {
+	if c.defaultValue != nil {
+		c.addErrorDefault()
+	}
+
+	if len(eng) == 0 {
+		c.defaultExpr = expr
+		return c
+	}
+	if c.defaultExprs == nil {
+		c.defaultExprs = make(map[Engine]string)
+	}
+	for _, v := range eng {
+		c.defaultExprs[v] = expr
+	}
+	return c
+}
+
 /*// Validate sets some validator to ckeck the value in the actual column.
 func (c *column) Validate(valid ...*validate.Validate) *column {
 	for _, v := range valid {
@@ -178,7 +268,7 @@ func (c *column) checkDefValue() bool {
 		}
 
 	case string:
-		if c.type_ != String {
+		if c.type_ != String && c.type_ != Char {
 			return false
 		}
 	case []byte:
@@ -202,6 +292,91 @@ func (c *column) checkDefValue() bool {
 	return true
 }
 
+// sqlSizeType returns the template of the SQL type for a column whose length is
+// set, or an empty string.
+func (c *column) sqlSizeType() string {
+	switch {
+	case c.type_ == Char:
+		return fmt.Sprintf("{{.Char}}(%d)", c.size)
+	case c.size != 0:
+		return fmt.Sprintf("{{.Varchar}}(%d)", c.size)
+	}
+	return ""
+}
+
+// indexName returns the name of the column to use in an index, with the
+// length of its prefix in MySQL.
+func (c *column) indexName() string {
+	if c.prefix == 0 {
+		return c.Name
+	}
+	return fmt.Sprintf("%s{{if eq .Engine \"MySQL\"}}(%d){{end}}", c.Name, c.prefix)
+}
+
+// hasDefaultExpr reports whether the column has an expression by default.
+func (c *column) hasDefaultExpr() bool {
+	return c.defaultExpr != "" || len(c.defaultExprs) != 0
+}
+
+// sqlDefault returns the clause DEFAULT of the column, like a template with
+// the expressions for every engine.
+func (c *column) sqlDefault() string {
+	if c.defaultValue != nil {
+		return " DEFAULT " + sqlLiteral(c.defaultValue)
+	}
+	if len(c.defaultExprs) == 0 {
+		if c.defaultExpr == "" {
+			return ""
+		}
+		return " DEFAULT " + c.defaultExpr
+	}
+
+	var s string
+	for _, eng := range []Engine{MySQL, Postgres, SQLite} {
+		if expr, ok := c.defaultExprs[eng]; ok {
+			if s != "" {
+				s += "{{else "
+			} else {
+				s += "{{"
+			}
+			s += fmt.Sprintf("if eq .Engine %q}} DEFAULT %s", eng.String(), expr)
+		}
+	}
+	if c.defaultExpr != "" {
+		s += "{{else}} DEFAULT " + c.defaultExpr
+	}
+	return s + "{{end}}"
+}
+
+// sqlLiteral returns the value formatted like a SQL literal, into a template
+// when its format depends on the engine.
+func sqlLiteral(v interface{}) string {
+	switch t := v.(type) {
+	case bool:
+		return boolAction(t)
+
+	case string:
+		s := "'" + strings.Replace(t, "'", "''", -1) + "'"
+		if strings.Contains(t, "\\") { // MySQL uses the backslash like escape character
+			s = fmt.Sprintf("{{if eq .Engine \"MySQL\"}}%s{{else}}%s{{end}}",
+				strings.Replace(s, "\\", "\\\\", -1), s)
+		}
+		return s
+
+	case []byte:
+		return fmt.Sprintf("{{if eq .Engine \"Postgres\"}}'\\x%x'{{else}}X'%[1]x'{{end}}", t)
+
+	case time.Time:
+		return "'" + t.UTC().Format("2006-01-02 15:04:05.999999") + "'"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func (c *column) addErrorDefault() {
+	columnsErr = append(columnsErr,
+		fmt.Sprintf("\n column %q only can have set a default value or expression", c.Name))
+}
+
 func (c *column) addErrorCons() {
 	columnsErr = append(columnsErr,
 		fmt.Sprintf("\n column %q only can have set a primary key, foreign key or unique constraint",
@@ -12,6 +12,8 @@ import (
 )
 
 func TestDefaultValue(t *testing.T) {
+	resetColumnsErr(t)
+
 	val1 := false
 	Column("married", Bool).Default(val1)
 	checkError(t, val1)
@@ -38,6 +40,8 @@ func TestDefaultValue(t *testing.T) {
 }
 
 func TestConstraintWinthIndex(t *testing.T) {
+	resetColumnsErr(t)
+
 	Column("foo", Int).PrimaryKey().Index(true)
 	Column("bar", Bool).Index(false).Unique()
 
@@ -46,8 +50,73 @@ func TestConstraintWinthIndex(t *testing.T) {
 	}
 }
 
+func TestSQLDefault(t *testing.T) {
+	resetColumnsErr(t)
+
+	tests := []struct {
+		col  *column
+		want string
+	}{
+		{Column("name", String).Default(`it's`), ` DEFAULT 'it''s'`},
+		{Column("path", String).Default(`a\b`),
+			` DEFAULT {{if eq .Engine "MySQL"}}'a\\b'{{else}}'a\b'{{end}}`},
+		{Column("data", Binary).Default([]byte("ab")),
+			` DEFAULT {{if eq .Engine "Postgres"}}'\x6162'{{else}}X'6162'{{end}}`},
+		{Column("created", DateTime).DefaultExpr("CURRENT_TIMESTAMP"), " DEFAULT CURRENT_TIMESTAMP"},
+		{Column("uuid", String).DefaultExpr("gen_random_uuid()", Postgres).DefaultExpr("(uuid())", MySQL),
+			`{{if eq .Engine "MySQL"}} DEFAULT (uuid()){{else if eq .Engine "Postgres"}} DEFAULT gen_random_uuid(){{end}}`},
+		{Column("code", String).DefaultExpr("(lower(hex(randomblob(4))))").DefaultExpr("(uuid())", MySQL),
+			`{{if eq .Engine "MySQL"}} DEFAULT (uuid()){{else}} DEFAULT (lower(hex(randomblob(4)))){{end}}`},
+	}
+	for _, tt := range tests {
+		if got := tt.col.sqlDefault(); got != tt.want {
+			t.Errorf("column %q: got %s, want %s", tt.col.Name, got, tt.want)
+		}
+	}
+
+	columnsErr = nil
+	Column("foo", Int8).Default(int8(1)).DefaultExpr("2")
+	if len(columnsErr) != 1 {
+		t.Error("expected to get an error at set both default value and expression")
+	}
+}
+
+func TestSize(t *testing.T) {
+	resetColumnsErr(t)
+
+	tests := []struct{ got, want string }{
+		{Column("code", String).Size(32).sqlSizeType(), "{{.Varchar}}(32)"},
+		{Column("state", Char).Size(2).sqlSizeType(), "{{.Char}}(2)"},
+		{Column("flag", Char).sqlSizeType(), "{{.Char}}(1)"},
+		{Column("body", String).sqlSizeType(), ""},
+		{Column("body", String).IndexPrefix(100).indexName(), `body{{if eq .Engine "MySQL"}}(100){{end}}`},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %s, want %s", tt.got, tt.want)
+		}
+	}
+
+	if c := Column("flag", Char); c.size != 1 {
+		t.Errorf("got size %d for a column of type Char without size, want 1", c.size)
+	}
+
+	columnsErr = nil
+	Column("age", Int).Size(3)
+	if len(columnsErr) != 1 {
+		t.Error("expected to get an error at set the size of a column which is not a string")
+	}
+}
+
 // * * *
 
+// resetColumnsErr clears the errors of the columns, which are kept in a global
+// variable, before and after of the test.
+func resetColumnsErr(t *testing.T) {
+	columnsErr = nil
+	t.Cleanup(func() { columnsErr = nil })
+}
+
 func checkError(t *testing.T, value interface{}) {
 	if len(columnsErr) != 0 {
 		t.Error("got error in column with value:", value)
@@ -0,0 +1,115 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Comment sets a comment for the column, which is added to the SQL definition
+// and to the field of the Go type like a doc comment.
+func (c *column) Comment(text string) *column {
+	checkComment(fmt.Sprintf("column %q", c.Name), text)
+	c.comment = text
+	return c
+}
+
+// Comment sets a comment for the table, which is added to the SQL definition
+// and to the Go type like a doc comment.
+func (t *table) Comment(text string) {
+	checkComment(fmt.Sprintf("table %q", t.Name), text)
+	t.comment = text
+}
+
+// checkComment checks that the comment can be used into the SQL templates.
+func checkComment(what, text string) {
+	if strings.Contains(text, "{{") {
+		log.Fatalf("%s: Comment(): the text can not have \"{{\"", what)
+	}
+}
+
+// sqlComment returns the comment like a string literal of the engine.
+// The SQL statements can not be split in several lines into the literals, so
+// the comment is set in a line.
+func sqlComment(text string) string {
+	s := strings.Replace(oneLine(text), "'", "''", -1)
+	if strings.Contains(s, "\\") { // MySQL uses the backslash like escape character
+		s = fmt.Sprintf("{{if eq .Engine \"MySQL\"}}%s{{else}}%s{{end}}",
+			strings.Replace(s, "\\", "\\\\", -1), s)
+	}
+	return "'" + s + "'"
+}
+
+// sqlBlockComment returns the text like a SQL comment, for SQLite.
+func sqlBlockComment(text string) string {
+	return "/* " + strings.Replace(oneLine(text), "*/", "* /", -1) + " */"
+}
+
+// oneLine returns the text in a line.
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// sqlColumnComment returns the comment to add to the definition of a column,
+// in MySQL and SQLite.
+func (c *column) sqlColumnComment() string {
+	if c.comment == "" {
+		return ""
+	}
+	return fmt.Sprintf("{{if eq .Engine \"MySQL\"}} COMMENT %s"+
+		"{{else if eq .Engine \"SQLite\"}} %s{{end}}",
+		sqlComment(c.comment), sqlBlockComment(c.comment))
+}
+
+// sqlTableComment returns the comment to add after the name of the table, in
+// SQLite.
+func (t *table) sqlTableComment() string {
+	if t.comment == "" {
+		return ""
+	}
+	return fmt.Sprintf("{{if eq .Engine \"SQLite\"}} %s{{end}}", sqlBlockComment(t.comment))
+}
+
+// sqlTableOptions returns the comment to add after the definition of the table,
+// in MySQL.
+func (t *table) sqlTableOptions() string {
+	if t.comment == "" {
+		return ""
+	}
+	return fmt.Sprintf("{{if eq .Engine \"MySQL\"}} COMMENT=%s{{end}}", sqlComment(t.comment))
+}
+
+// sqlCommentOn returns the statements to set the comments of the table and of
+// its columns, in Postgres.
+func (t *table) sqlCommentOn() string {
+	var stmts []string
+
+	if t.comment != "" {
+		stmts = append(stmts, fmt.Sprintf("COMMENT ON TABLE %s IS %s;",
+			t.sqlName, sqlComment(t.comment)))
+	}
+	for _, c := range t.Columns {
+		if c.comment != "" {
+			stmts = append(stmts, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;",
+				t.sqlName, quoteSQL(c.Name), sqlComment(c.comment)))
+		}
+	}
+	if len(stmts) == 0 {
+		return ""
+	}
+	return "{{if eq .Engine \"Postgres\"}}" + strings.Join(stmts, "\n") + "\n{{end}}"
+}
+
+// goComment returns the text like a Go comment, or an empty string.
+func goComment(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "// " + strings.Replace(strings.TrimSpace(text), "\n", "\n// ", -1) + "\n"
+}
@@ -0,0 +1,35 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import "testing"
+
+func TestComment(t *testing.T) {
+	resetColumnsErr(t)
+
+	md := Metadata("model", Postgres)
+	tb := Table("note", md,
+		Column("id", Int).PrimaryKey(),
+		Column("body", String).Comment("User's text,\nin Markdown"),
+	)
+	tb.Comment("Notes */")
+
+	tests := []struct{ got, want string }{
+		{tb.Columns[1].sqlColumnComment(), `{{if eq .Engine "MySQL"}} COMMENT 'User''s text, in Markdown'` +
+			`{{else if eq .Engine "SQLite"}} /* User's text, in Markdown */{{end}}`},
+		{tb.sqlTableComment(), `{{if eq .Engine "SQLite"}} /* Notes * / */{{end}}`},
+		{tb.sqlCommentOn(), `{{if eq .Engine "Postgres"}}COMMENT ON TABLE note IS 'Notes */';` + "\n" +
+			`COMMENT ON COLUMN note.body IS 'User''s text, in Markdown';` + "\n{{end}}"},
+		{goComment("User's text,\nin Markdown"), "// User's text,\n// in Markdown\n"},
+		{sqlComment(`a\b`), `'{{if eq .Engine "MySQL"}}a\\b{{else}}a\b{{end}}'`},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %s\nwant %s", tt.got, tt.want)
+		}
+	}
+}
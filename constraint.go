@@ -0,0 +1,220 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"strings"
+)
+
+// maxNameLen is the maximum length of the identifiers in every engine.
+var maxNameLen = map[Engine]int{
+	Postgres: 63,
+	MySQL:    64,
+}
+
+// constraint represents a constraint defined at table level.
+type constraint struct {
+	kind    constraintType
+	name    string // set through Name
+	table   *table
+	columns []string
+
+	// Foreign key
+	fkTable   string
+	fkColumns []string
+
+	// Check
+	expr string
+}
+
+// Name sets the name of the constraint, instead of the name by default.
+func (c *constraint) Name(name string) {
+	what := fmt.Sprintf("table %q: Name(%q)", c.table.Name, name)
+
+	if name == "" || strings.ContainsAny(name, " \t\n\"'`{}") {
+		log.Fatalf("%s: invalid name for a constraint", what)
+	}
+	checkNameLen(c.table.meta, what, name)
+	c.name = name
+}
+
+// Check creates a check constraint with a SQL expression.
+func (t *table) Check(expr string) *constraint {
+	if strings.Contains(expr, "{{") {
+		log.Fatalf("table %q: Check(): the expression can not have \"{{\"", t.Name)
+	}
+
+	c := &constraint{kind: checkCons, table: t, expr: expr}
+	t.checkCons = append(t.checkCons, c)
+	return c
+}
+
+// constraints returns the constraints defined at table level.
+func (t *table) constraints() []*constraint {
+	var list []*constraint
+
+	if t.pkCons != nil {
+		list = append(list, t.pkCons)
+	}
+	list = append(list, t.uniqueCons...)
+	list = append(list, t.fkCons...)
+	return append(list, t.checkCons...)
+}
+
+// checkConstraintNames checks that the names of the constraints are not used
+// twice in the database, including the names given by Postgres to the
+// constraints defined at column level.
+func (md *metadata) checkConstraintNames() {
+	used := make(map[string]string) // name of the constraint: table
+
+	for _, t := range md.tables {
+		var names []string
+
+		for _, col := range t.Columns {
+			if col.cons&primaryKey != 0 {
+				names = append(names, postgresName(t.Name, "", "pkey"))
+			}
+			if col.cons&uniqueCons != 0 {
+				names = append(names, postgresName(t.Name, col.Name, "key"))
+			}
+			if col.cons&foreignKey != 0 {
+				names = append(names, postgresName(t.Name, col.Name, "fkey"))
+			}
+		}
+		for _, c := range t.constraints() {
+			names = append(names, c.defaultName())
+		}
+
+		for _, name := range names {
+			if tableName, ok := used[name]; ok {
+				log.Fatalf("table %q: constraint %q: the name is already used in table %q",
+					t.Name, name, tableName)
+			}
+			used[name] = t.Name
+		}
+	}
+}
+
+// defaultName returns the name of the constraint, which is got like in
+// Postgres when it is not set: "<table>_pkey", "<table>_<columns>_key",
+// "<table>_<columns>_fkey" and "<table>_check", with a number for the next
+// check constraints.
+func (c *constraint) defaultName() string {
+	if c.name != "" {
+		return c.name
+	}
+
+	switch c.kind {
+	case primaryKey:
+		return c.table.Name + "_pkey"
+	case uniqueCons:
+		return c.table.Name + "_" + strings.Join(c.columns, "_") + "_key"
+	case foreignKey:
+		return c.table.Name + "_" + strings.Join(c.columns, "_") + "_fkey"
+	}
+
+	for i, v := range c.table.checkCons {
+		if v == c && i != 0 {
+			return fmt.Sprintf("%s_check%d", c.table.Name, i)
+		}
+	}
+	return c.table.Name + "_check"
+}
+
+// nameFor returns the name of the constraint for the engine.
+func (c *constraint) nameFor(eng Engine) string {
+	return engineName(c.defaultName(), eng)
+}
+
+// sqlName returns the clause to name the constraint.
+func (c *constraint) sqlName() string {
+	return "CONSTRAINT " + sqlEngineName(c.defaultName()) + " "
+}
+
+// checkNameLen checks that a name set by the user is not longer than the limit
+// of the engines used.
+func checkNameLen(md *metadata, what, name string) {
+	for _, eng := range md.engines {
+		if max := maxNameLen[eng]; max != 0 && len(name) > max {
+			log.Fatalf("%s: the name is longer than %d characters, the limit in %s",
+				what, max, eng)
+		}
+	}
+}
+
+// engineName returns the name to use in the engine. A name by default longer
+// than the limit of the engine is truncated, adding a hash of the full name so
+// it is still unique.
+func engineName(name string, eng Engine) string {
+	max := maxNameLen[eng]
+
+	if max == 0 || len(name) <= max {
+		return name
+	}
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("%s_%08x", name[:max-9], h.Sum32())
+}
+
+// postgresName returns the name given by Postgres to a constraint without name,
+// "<name1>_<name2>_<label>", truncated like Postgres does to fit in 63 bytes:
+// the longer of name1 and name2 is shortened until both fit.
+func postgresName(name1, name2, label string) string {
+	max := maxNameLen[Postgres] - len(label) - 1
+	if name2 != "" {
+		max--
+	}
+
+	n1, n2 := len(name1), len(name2)
+	for n1+n2 > max {
+		if n1 > n2 {
+			n1--
+		} else {
+			n2--
+		}
+	}
+
+	name := name1[:n1]
+	if name2 != "" {
+		name += "_" + name2[:n2]
+	}
+	return name + "_" + label
+}
+
+// sqlEngineName returns the name to use into a template, which is different by
+// engine when it is longer than some limit.
+func sqlEngineName(name string) string {
+	pgName, myName := engineName(name, Postgres), engineName(name, MySQL)
+
+	if pgName == name && myName == name {
+		return quoteSQL(name)
+	}
+	return fmt.Sprintf("{{if eq .Engine \"Postgres\"}}%s"+
+		"{{else if eq .Engine \"MySQL\"}}%s{{else}}%s{{end}}",
+		quoteSQL(pgName), quoteSQL(myName), quoteSQL(name))
+}
+
+// sql returns the definition of the constraint.
+func (c *constraint) sql() string {
+	def := ""
+
+	switch c.kind {
+	case primaryKey:
+		def = fmt.Sprintf("PRIMARY KEY (%s)", quoteNamesSQL(c.columns))
+	case uniqueCons:
+		def = fmt.Sprintf("UNIQUE (%s)", quoteNamesSQL(c.columns))
+	case foreignKey:
+		def = fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+			quoteNamesSQL(c.columns), quoteSQL(c.fkTable), quoteNamesSQL(c.fkColumns))
+	case checkCons:
+		def = fmt.Sprintf("CHECK (%s)", c.expr)
+	}
+	return c.sqlName() + def
+}
@@ -0,0 +1,69 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConstraint(t *testing.T) {
+	resetColumnsErr(t)
+
+	md := Metadata("model", Postgres, MySQL)
+	long := strings.Repeat("c", 30)
+	tb := Table("account", md,
+		Column("num", Int),
+		Column("code", String),
+		Column(long+"_1", Int),
+		Column(long+"_2", Int),
+	)
+	pk := tb.PrimaryKey("num")
+	uq1 := tb.Unique("code")
+	uq1.Name("uq_account_code")
+	uq2 := tb.Unique(long+"_1", long+"_2")
+	ck1 := tb.Check("num > 0")
+	ck2 := tb.Check("code <> ''")
+
+	tests := []struct{ got, want string }{
+		{pk.sql(), "CONSTRAINT account_pkey PRIMARY KEY (num)"},
+		{uq1.sql(), "CONSTRAINT uq_account_code UNIQUE (code)"},
+		{ck1.sql(), "CONSTRAINT account_check CHECK (num > 0)"},
+		{ck2.defaultName(), "account_check1"},
+		{uq2.nameFor(Postgres), uq2.defaultName()[:54] + "_775571bf"},
+		{uq2.nameFor(MySQL), uq2.defaultName()[:55] + "_775571bf"},
+		{uq2.nameFor(SQLite), "account_" + long + "_1_" + long + "_2_key"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %s\nwant %s", tt.got, tt.want)
+		}
+	}
+	// Truncation of the names given by Postgres
+	name := postgresName(strings.Repeat("t", 40), strings.Repeat("c", 30), "fkey")
+	if want := strings.Repeat("t", 29) + "_" + strings.Repeat("c", 28) + "_fkey"; name != want {
+		t.Errorf("got name %s\nwant %s", name, want)
+	}
+	if name = postgresName("account", "", "pkey"); name != "account_pkey" {
+		t.Errorf("got name %s", name)
+	}
+
+	tb2 := Table("member", md,
+		Column("id", Int).PrimaryKey(),
+		Column("user", Int),
+	)
+	if got := tb2.Unique("user").sql(); got != "CONSTRAINT member_user_key UNIQUE ({{.Q}}user{{.Q}})" {
+		t.Errorf("got %s", got)
+	}
+
+	if n := len(tb.uniqueCons); n != 2 {
+		t.Errorf("got %d unique constraints, want 2", n)
+	}
+	if !tb.isUniqueKey([]string{long + "_1", long + "_2"}) {
+		t.Error("expected an unique key")
+	}
+}
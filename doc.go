@@ -24,20 +24,53 @@ Features
 
 Dialect implemented for PostgreSQL, MySQL, SQLite3
 Schema generation
-Support primary and foreign keys, indexes and unique constraints, also for composites
-Default values
-Enumerations
+Support primary and foreign keys, indexes, unique and check constraints, also for composites; the constraints at table level have a name, by default or set through Name
+Indexes with name, descending columns, expressions, prefixes in MySQL, and partial indexes, methods and included columns where the engines support them
+Default values, also SQL expressions by engine (DefaultExpr), and strings in MySQL through VARCHAR
+Strings with a maximum or fixed length (Size, type Char), checked by the generated method Validate, and prefixes of the indexes in MySQL (IndexPrefix)
+Comments of tables and columns in SQL, and like doc comments in the Go types (Comment)
+Enumerations, and synchronization of their tables with the Go code (SyncEnums)
+Statements to get, update, delete and check a row by its primary key
+Scanning of rows by the name of the columns
+Insertion of multiple rows by statement
+Upsert statements, to update a row at inserting it if its key already exists
+Methods to get the rows related through foreign keys, also for many-to-many relations, and to load them for several rows at once
+Methods which run through a database, transaction or connection (interface Querier)
+Cascade delete in the application layer, following the foreign keys of the model
+Check of orphan rows, whose foreign keys reference rows which do not exist
+Transactions retried on serialization failures and deadlocks
+Classification of constraint violations returned by the drivers (type ConstraintError)
+Configurable names of the Go types and fields (CamelName, GoName), with tags "db" and "json"
+Detection of Go identifiers which collide or are not valid, or their renaming (RenameCollisions)
+Groups of columns reused in several tables, with their indexes and constraints (Mixin), optionally embedded in the Go types
+Columns with the times of creation and update (Timestamps), and soft delete (SoftDelete)
 
 Enumeration
 
 The function "Enum" allows to create a table with the given names whose values
 will be the same in both SQL tables and Go code.
 
+In Go, every enumeration has its own type, with the methods to get the name of
+a value (String), to parse it (Parse[Type]), to list all values (Values), and to
+convert it from and to JSON (MarshalText, UnmarshalText) and SQL (Scan, Value),
+rejecting the values out of the enumeration. The columns which are foreign keys
+to a table of enumeration use that type.
+
+The names of an enumeration are unique. Its table can have columns with extra
+attributes, like a label or a sort order, added through the method "Attrs" and
+whose values are given for every value through the method "Value"; in Go, they
+are in a map keyed by the type of the enumeration ([Type]Attrs), and returned
+by the method Attr.
+
 Some SQL engines have a type to handle enumerations but they have some issues
 as explained here:
 
 http://komlenic.com/244/8-reasons-why-mysqls-enum-data-type-is-evil/
 
+Anyway, the function "NativeEnum" defines an enumeration with the representation
+native of every engine: a type in PostgreSQL, the type ENUM in MySQL, and a TEXT
+column with a CHECK constraint in SQLite3; its Go type is based on a string.
+
 Datetime
 
 That data must be stored in UTC. By this reason, the data type for DateTime in
@@ -46,6 +79,17 @@ PostgreSQL is defined with "timestamp without time zone".
 It is used "time.Time{}" to get the initial value to zero, which is better than
 using NULL values.
 
+The method "Timestamps" of a table adds the columns "created_at" and
+"updated_at", set by the database to the current time; the generated statement
+to update a row sets "updated_at", which is also set through "ON UPDATE" in
+MySQL and through a trigger in PostgreSQL and SQLite3. In MySQL, the time zone
+of the server has to be UTC.
+
+The method "SoftDelete" adds the column "deleted_at", whose Go type is
+sql.NullTime. The generated method Delete marks the row like deleted, and the
+statements to get, update and check a row, and to get the related rows, skip the
+rows marked. The functions "DeleteCascade" and "CheckOrphans" handle all rows.
+
 Unsupported
 
 The null handling is very different in every SQL engine (http://www.sqlite.org/nulls.html),
@@ -67,9 +111,19 @@ For testing into a SQL engine, there is to run:
 
 See files 'test/[engine]_task.go' to know how databases were configured.
 
+To report the orphan rows in a database, there is to run:
+
+   test> gotask check-orphans postgres|mysql|sqlite [data source name]
+
+The function "CheckOrphans" does it from the relations of the Go file generated.
+
 Avoid cascades due to being magic; instead, I handle it from the application layer.
 http://stackoverflow.com/questions/59297/when-why-to-use-cascading-in-sql-server
 
+The types of the tables referenced by foreign keys have the method "DeleteCascade",
+which deletes the rows that depend on a row, children first, within a transaction;
+it can also report the rows that would be deleted, without deleting them.
+
 Usage
 
 You have to create a directory for the model's file or files; as suggestion,
@@ -78,6 +132,18 @@ name it "ModSQL". Then, from the project's directory run "go run ModSQL/[file].g
 The Go file generated uses the constant "ENGINE" according to the given values
 in the function "Metadata", using the first engine by default.
 
+Incompatible changes
+
+The type Statements does not export the map of prepared statements, Stmt,
+since they can be prepared on its first use and closed concurrently; the
+statement for a key is got through the method Get, or it is run directly
+through ExecContext, QueryContext or QueryRowContext. The method Prepare returns
+an error instead of exiting, so the callers have to check it.
+
+The interface Modeler has the methods Insert, Columns and Scan, and StmtInsert
+returns an error too; the Go files generated by a previous version have to be
+generated again.
+
 Note
 
 There are public methods which are not showed in the documentation due they
@@ -0,0 +1,446 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// nativeEnum represents an enumeration using the type native of every engine.
+type nativeEnum struct {
+	Name   string
+	meta   *metadata
+	values []string
+
+	goName     string
+	constNames []string // names of the constants
+}
+
+// NativeEnum defines an enumeration using the representation native of every
+// engine: a type created through "CREATE TYPE" in Postgres, the type ENUM in
+// MySQL, and a TEXT column with a CHECK constraint in SQLite. Its values are
+// stored like strings, instead of in a table like with Enum.
+// The columns of the enumeration are defined through its method Column.
+func NativeEnum(name string, meta *metadata, value ...string) *nativeEnum {
+	if len(value) == 0 {
+		log.Fatalf("native enumeration %q: no values", name)
+	}
+	for _, v := range meta.nativeEnums {
+		if v.Name == name {
+			log.Fatalf("native enumeration %q: already defined", name)
+		}
+	}
+
+	e := &nativeEnum{Name: name, meta: meta, values: value}
+	meta.nativeEnums = append(meta.nativeEnums, e)
+	return e
+}
+
+// Column defines a new column whose type is the enumeration.
+func (e *nativeEnum) Column(name string) *column {
+	c := Column(name, String)
+	c.enum = e
+	return c
+}
+
+// GoName sets the name of the Go type of the enumeration.
+func (e *nativeEnum) GoName(name string) *nativeEnum {
+	e.goName = name
+	return e
+}
+
+// typeName returns the name of the Go type of the enumeration.
+func (e *nativeEnum) typeName() string {
+	if e.goName != "" {
+		return e.goName
+	}
+	return e.meta.naming(e.Name)
+}
+
+// constants returns the names of the constants of the enumeration.
+func (e *nativeEnum) constants() []string {
+	if len(e.constNames) != 0 {
+		return e.constNames
+	}
+
+	prefix := enumPrefix(e.Name)
+	names := make([]string, len(e.values))
+	for i, v := range e.values {
+		names[i] = prefix + strings.ToUpper(v)
+	}
+	return names
+}
+
+// sqlValues returns the values of the enumeration quoted for SQL.
+func (e *nativeEnum) sqlValues() string {
+	values := make([]string, len(e.values))
+	for i, v := range e.values {
+		values[i] = "'" + strings.Replace(v, "'", "''", -1) + "'"
+	}
+	return strings.Join(values, ", ")
+}
+
+// sqlCreate returns the statement to create the type, only for Postgres.
+func (e *nativeEnum) sqlCreate() string {
+	return fmt.Sprintf("{{if eq .Engine \"Postgres\"}}\nCREATE TYPE %s AS ENUM (%s);\n{{end}}",
+		quoteSQL(e.Name), e.sqlValues())
+}
+
+// sqlDrop returns the statement to drop the type, only for Postgres.
+func (e *nativeEnum) sqlDrop() string {
+	return fmt.Sprintf("{{if eq .Engine \"Postgres\"}}\nDROP TYPE %s;{{end}}", quoteSQL(e.Name))
+}
+
+// sqlType returns the template of the SQL type for a column of the enumeration.
+func (e *nativeEnum) sqlType(column string) string {
+	return fmt.Sprintf("{{if eq .Engine \"Postgres\"}}%s"+
+		"{{else if eq .Engine \"MySQL\"}}ENUM(%s)"+
+		"{{else}}TEXT CHECK (%s IN (%[2]s)){{end}}",
+		quoteSQL(e.Name), e.sqlValues(), quoteSQL(column))
+}
+
+// genNativeEnum generates the Go type for a native enumeration, which is
+// stored like a string.
+func (md *metadata) genNativeEnum(e *nativeEnum) string {
+	md.goImports["database/sql/driver"] = true
+	md.goImports["fmt"] = true
+
+	name := e.typeName()
+	code := fmt.Sprintf("\n// %s is the native enumeration %q.\ntype %s string\n\nconst (\n",
+		name, e.Name, name)
+
+	consts := e.constants()
+	for i, v := range e.values {
+		code += fmt.Sprintf("%s %s = %q\n", consts[i], name, v)
+	}
+	code += ")\n"
+
+	return code + fmt.Sprintf(`
+// IsValid reports whether the value is in the enumeration.
+func (e %[1]s) IsValid() bool {
+	switch e {
+	case %[2]s:
+		return true
+	}
+	return false
+}
+
+// Values returns all values of the enumeration.
+func (%[1]s) Values() []%[1]s {
+	return []%[1]s{%[2]s}
+}
+
+// Parse%[1]s returns the value of the enumeration with the given name.
+func Parse%[1]s(s string) (%[1]s, error) {
+	if e := %[1]s(s); e.IsValid() {
+		return e, nil
+	}
+	return "", fmt.Errorf("invalid name for %[1]s: %%q", s)
+}
+
+func (e %[1]s) MarshalText() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid value for %[1]s: %%q", string(e))
+	}
+	return []byte(e), nil
+}
+
+func (e *%[1]s) UnmarshalText(text []byte) error {
+	v, err := Parse%[1]s(string(text))
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (e *%[1]s) Scan(src interface{}) error {
+	var s string
+
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("scan %[1]s: unsupported type %%T", src)
+	}
+
+	v, err := Parse%[1]s(s)
+	if err != nil {
+		return fmt.Errorf("scan %[1]s: %%s", err)
+	}
+	*e = v
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (e %[1]s) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid value for %[1]s: %%q", string(e))
+	}
+	return string(e), nil
+}
+`,
+		name, strings.Join(consts, ", "))
+}
+
+// genEnum generates the Go type for an enumeration, with its constants and
+// the methods to convert it from and to text, and from and to SQL.
+func (md *metadata) genEnum(t *table) string {
+	md.goImports["database/sql/driver"] = true
+	md.goImports["fmt"] = true
+	md.goImports["strconv"] = true
+
+	name := t.typeName()
+	start := ""
+	if t.startEnum != 0 {
+		start = " + " + strconv.Itoa(t.startEnum)
+	}
+
+	code := fmt.Sprintf("\n// %s is the enumeration of the table %q.\n", name, t.Name)
+	if t.comment != "" {
+		code += "//\n" + goComment(t.comment)
+	}
+	code += fmt.Sprintf("type %s %s\n\nconst (\n", name, t.Columns[0].type_.goString())
+
+	values := make([]string, len(t.data))
+	for i, v := range t.enumConstants() {
+		if i == 0 {
+			code += fmt.Sprintf("%s %s = iota%s\n", v, name, start)
+		} else {
+			code += v + "\n"
+		}
+		values[i] = strconv.Quote(t.data[i][1].(string))
+	}
+	code += ")\n"
+
+	return code + fmt.Sprintf(`
+var %[2]s = []string{%[4]s}
+
+// String returns the name of the value in the enumeration.
+func (e %[1]s) String() string {
+	if e.IsValid() {
+		return %[2]s[int(e)-%[3]d]
+	}
+	return "%[1]s(" + strconv.FormatInt(int64(e), 10) + ")"
+}
+
+// IsValid reports whether the value is in the enumeration.
+func (e %[1]s) IsValid() bool {
+	return int(e) >= %[3]d && int(e)-%[3]d < len(%[2]s)
+}
+
+// Values returns all values of the enumeration.
+func (%[1]s) Values() []%[1]s {
+	values := make([]%[1]s, len(%[2]s))
+	for i := range values {
+		values[i] = %[1]s(i + %[3]d)
+	}
+	return values
+}
+
+// Parse%[1]s returns the value of the enumeration with the given name.
+func Parse%[1]s(s string) (%[1]s, error) {
+	for i, v := range %[2]s {
+		if v == s {
+			return %[1]s(i + %[3]d), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid name for %[1]s: %%q", s)
+}
+
+func (e %[1]s) MarshalText() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid value for %[1]s: %%d", e)
+	}
+	return []byte(e.String()), nil
+}
+
+func (e *%[1]s) UnmarshalText(text []byte) error {
+	v, err := Parse%[1]s(string(text))
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (e *%[1]s) Scan(src interface{}) error {
+	var n int64
+	var err error
+
+	switch v := src.(type) {
+	case int64:
+		n = v
+	case []byte:
+		n, err = strconv.ParseInt(string(v), 10, 64)
+	case string:
+		n, err = strconv.ParseInt(v, 10, 64)
+	default:
+		err = fmt.Errorf("unsupported type %%T", src)
+	}
+	if err != nil {
+		return fmt.Errorf("scan %[1]s: %%s", err)
+	}
+
+	if v := %[1]s(n); int64(v) == n && v.IsValid() {
+		*e = v
+		return nil
+	}
+	return fmt.Errorf("scan %[1]s: invalid value %%d", n)
+}
+
+// Value implements the driver.Valuer interface.
+func (e %[1]s) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid value for %[1]s: %%d", e)
+	}
+	return int64(e), nil
+}
+`,
+		name, t.enumNamesVar(), t.startEnum, strings.Join(values, ", ")) +
+		t.genEnumAttrs()
+}
+
+// genEnumAttrs generates the Go type with the extra attributes of an
+// enumeration, and a map with their values for every value.
+func (t *table) genEnumAttrs() string {
+	if len(t.Columns) == 2 {
+		return ""
+	}
+	name := t.typeName()
+	attrs := t.Columns[2:]
+
+	code := fmt.Sprintf("\n// %sAttr has the extra attributes of a value of %[1]s.\ntype %[1]sAttr struct {\n", name)
+	for i := range attrs {
+		code += fmt.Sprintf("%s%s %s %s\n", goComment(attrs[i].comment),
+			t.fieldName(attrs[i].Name), t.goType(&attrs[i]), t.fieldTags(&attrs[i]))
+	}
+	code += fmt.Sprintf("}\n\n// %sAttrs has the extra attributes of every value of %[1]s.\nvar %[1]sAttrs = map[%[1]s]%[1]sAttr{\n", name)
+
+	consts := t.enumConstants()
+	for i, row := range t.data {
+		fields := make([]string, len(attrs))
+		for j := range attrs {
+			fields[j] = fmt.Sprintf("%s: %#v", t.fieldName(attrs[j].Name), row[j+2])
+		}
+		code += fmt.Sprintf("%s: {%s},\n", consts[i], strings.Join(fields, ", "))
+	}
+
+	return code + fmt.Sprintf(`}
+
+// Attr returns the extra attributes of the value.
+func (e %[1]s) Attr() %[1]sAttr {
+	return %[1]sAttrs[e]
+}
+`, name)
+}
+
+// enumNamesVar returns the name of the variable with the names of the values
+// of the enumeration.
+func (t *table) enumNamesVar() string {
+	return strings.TrimSuffix(paramName(t.typeName(), nil), "_") + "Names"
+}
+
+// goType returns the Go type of the column, which is the type of the
+// enumeration when the column is a foreign key to a table of enumeration, or
+// when it is a native enumeration; the columns of type DateTime which can be
+// NULL use sql.NullTime.
+func (t *table) goType(c *column) string {
+	if c.enum != nil {
+		return c.enum.typeName()
+	}
+	if e := t.enumReferenced(c.Name); e != nil {
+		return e.typeName()
+	}
+	if c.null && c.type_ == DateTime {
+		return "sql.NullTime"
+	}
+	return c.type_.goString()
+}
+
+// enumReferenced returns the table of enumeration referenced by the column,
+// or nil if it does not reference one.
+func (t *table) enumReferenced(name string) *table {
+	refTable := ""
+	if c := t.column(name); c != nil && c.cons&foreignKey != 0 {
+		refTable = c.fkTable
+	}
+	for _, fk := range t.fkCons {
+		if len(fk.columns) == 1 && fk.columns[0] == name {
+			refTable = fk.fkTable
+		}
+	}
+	if refTable == "" {
+		return nil
+	}
+
+	for _, v := range t.meta.tables {
+		if v.Name == refTable && v.isEnum {
+			return v
+		}
+	}
+	return nil
+}
+
+// genSyncEnums generates the Go code to synchronize the tables of enumeration
+// in the database with the model.
+func (md *metadata) genSyncEnums() string {
+	code := ""
+	for _, t := range md.tables {
+		if !t.isEnum {
+			continue
+		}
+		columns := ""
+		if len(t.Columns) > 2 {
+			names := make([]string, len(t.Columns)-2)
+			for i := range names {
+				names[i] = strconv.Quote(t.Columns[i+2].Name)
+			}
+			columns = fmt.Sprintf(", Columns: []string{%s}", strings.Join(names, ", "))
+		}
+
+		values := make([]string, len(t.data))
+		for i, v := range t.data {
+			attrs := ""
+			if len(v) > 2 {
+				a := make([]string, len(v)-2)
+				for j := range a {
+					a[j] = fmt.Sprintf("%#v", v[j+2])
+				}
+				attrs = fmt.Sprintf(", Attrs: []interface{}{%s}", strings.Join(a, ", "))
+			}
+			values[i] = fmt.Sprintf("{ID: %v, Name: %q%s}", v[0], v[1], attrs)
+		}
+		code += fmt.Sprintf("{Name: %q%s, Values: []modsql.EnumValue{%s}},\n",
+			t.Name, columns, strings.Join(values, ", "))
+	}
+	if code == "" {
+		return ""
+	}
+	md.goImports["context"] = true
+
+	return fmt.Sprintf(`
+// EnumTables has the values of the tables of enumeration.
+var EnumTables = []modsql.EnumTable{
+%s}
+
+// SyncEnums makes the tables of enumeration match the Go constants, inserting
+// or updating their rows; the rows which are not in the Go code are reported,
+// but they are not deleted. If verifyOnly is true, it only reports the
+// differences.
+func SyncEnums(ctx context.Context, q modsql.Querier, verifyOnly bool) ([]modsql.EnumReport, error) {
+	return modsql.SyncEnums(ctx, q, ENGINE, EnumTables, verifyOnly)
+}
+`, code)
+}
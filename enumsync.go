@@ -0,0 +1,257 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// An EnumTable represents the values of a table of enumeration in the model.
+type EnumTable struct {
+	Name    string
+	Columns []string // columns of the extra attributes
+	Values  []EnumValue
+}
+
+// An EnumValue represents a row of a table of enumeration.
+type EnumValue struct {
+	ID    int64
+	Name  string
+	Attrs []interface{} // values of the extra attributes
+}
+
+// An EnumReport represents the differences between a table of enumeration in
+// the database and in the model, by the identifier of the rows.
+type EnumReport struct {
+	Table   string
+	Missing []int64 // rows of the model which are not in the database
+	Changed []int64 // rows with a different name or attributes in the database
+	Extra   []int64 // rows of the database which are not in the model
+}
+
+func (r EnumReport) String() string {
+	var s []string
+	add := func(what string, ids []int64) {
+		if len(ids) != 0 {
+			s = append(s, fmt.Sprintf("%s %v", what, ids))
+		}
+	}
+	add("missing", r.Missing)
+	add("changed", r.Changed)
+	add("extra", r.Extra)
+
+	return fmt.Sprintf("enumeration %q: %s", r.Table, strings.Join(s, ", "))
+}
+
+// isEmpty reports whether there are no differences.
+func (r EnumReport) isEmpty() bool {
+	return len(r.Missing) == 0 && len(r.Changed) == 0 && len(r.Extra) == 0
+}
+
+// SyncEnums makes the tables of enumeration in the database match the model,
+// within a transaction like in BatchInsert, inserting the missing rows and
+// updating the rows whose name or extra attributes have changed. The rows which
+// are not in the model are never deleted since they could be referenced; they
+// are only reported.
+//
+// It returns the differences found before of the synchronization, by table.
+// If verifyOnly is true, the database is not modified, so it can be used with
+// a read-only connection.
+// It is to be called from the Go code generated.
+func SyncEnums(ctx context.Context, q Querier, eng Engine, tables []EnumTable, verifyOnly bool) ([]EnumReport, error) {
+	var reports []EnumReport
+
+	for _, t := range tables {
+		name := quoteStatementSQL(t.Name)
+		var report EnumReport
+
+		err := withTx(ctx, q, t.Name, func(tx *sql.Tx) error {
+			current, err := enumRows(ctx, tx, eng, name, t.Columns)
+			if err != nil {
+				return err
+			}
+			if report = diffEnum(t, current); verifyOnly || report.isEmpty() {
+				return nil
+			}
+
+			values := make(map[int64]EnumValue, len(t.Values))
+			for _, v := range t.Values {
+				values[v.ID] = v
+			}
+
+			columns := "id, name"
+			set := "name = {P}"
+			for _, v := range t.Columns {
+				columns += ", " + quoteStatementSQL(v)
+				set += ", " + quoteStatementSQL(v) + " = {P}"
+			}
+			insert := SQLReplacer(eng, "INSERT INTO "+name+" ("+columns+") VALUES({P}"+
+				strings.Repeat(", {P}", len(t.Columns)+1)+")")
+			update := SQLReplacer(eng, "UPDATE "+name+" SET "+set+" WHERE id = {P}")
+
+			for _, id := range report.Missing {
+				args := append([]interface{}{id, values[id].Name}, values[id].Attrs...)
+				if _, err = tx.ExecContext(ctx, insert, args...); err != nil {
+					return ClassifyError(eng, err)
+				}
+			}
+			for _, id := range report.Changed {
+				args := append([]interface{}{values[id].Name}, values[id].Attrs...)
+				if _, err = tx.ExecContext(ctx, update, append(args, id)...); err != nil {
+					return ClassifyError(eng, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if !report.isEmpty() {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
+
+// enumRows returns the rows of a table of enumeration, with the values of the
+// extra attributes.
+func enumRows(ctx context.Context, tx *sql.Tx, eng Engine, table string, columns []string) (map[int64]EnumValue, error) {
+	query := "SELECT id, name"
+	for _, v := range columns {
+		query += ", " + quoteStatementSQL(v)
+	}
+	rows, err := tx.QueryContext(ctx, SQLReplacer(eng, query+" FROM "+table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[int64]EnumValue)
+	for rows.Next() {
+		v := EnumValue{Attrs: make([]interface{}, len(columns))}
+		dest := []interface{}{&v.ID, &v.Name}
+		for i := range v.Attrs {
+			dest = append(dest, &v.Attrs[i])
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		res[v.ID] = v
+	}
+	return res, rows.Err()
+}
+
+// diffEnum returns the differences between the rows of a table of enumeration
+// in the database and in the model.
+func diffEnum(t EnumTable, current map[int64]EnumValue) EnumReport {
+	r := EnumReport{Table: t.Name}
+	inModel := make(map[int64]bool, len(t.Values))
+
+	for _, v := range t.Values {
+		inModel[v.ID] = true
+
+		row, found := current[v.ID]
+		switch {
+		case !found:
+			r.Missing = append(r.Missing, v.ID)
+		case row.Name != v.Name || !equalAttrs(v.Attrs, row.Attrs):
+			r.Changed = append(r.Changed, v.ID)
+		}
+	}
+	for id := range current {
+		if !inModel[id] {
+			r.Extra = append(r.Extra, id)
+		}
+	}
+	sort.Slice(r.Extra, func(i, j int) bool { return r.Extra[i] < r.Extra[j] })
+
+	return r
+}
+
+// equalAttrs reports whether the attributes of the model have the same values
+// than the ones got from the database, whose types depend on the driver: the
+// strings could be got like []byte, the booleans like integers and the
+// integers of the model like int64.
+func equalAttrs(model, db []interface{}) bool {
+	if len(model) != len(db) {
+		return false
+	}
+	for i := range model {
+		if !equalAttr(model[i], db[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalAttr(model, db interface{}) bool {
+	if b, ok := db.([]byte); ok {
+		db = string(b)
+	}
+	if model == nil || db == nil {
+		return model == nil && db == nil
+	}
+	if t, ok := model.(time.Time); ok {
+		dt, ok := db.(time.Time)
+		return ok && t.Equal(dt)
+	}
+
+	m, d := reflect.ValueOf(model), reflect.ValueOf(db)
+	if mi, ok := integer(m); ok {
+		if d.Kind() == reflect.Float32 || d.Kind() == reflect.Float64 {
+			return float64(mi) == d.Float()
+		}
+		di, ok := integer(d)
+		return ok && mi == di
+	}
+
+	switch m.Kind() {
+	case reflect.Float32, reflect.Float64:
+		var df float64
+		switch d.Kind() {
+		case reflect.Float32, reflect.Float64:
+			df = d.Float()
+		default:
+			di, ok := integer(d)
+			if !ok {
+				return false
+			}
+			df = float64(di)
+		}
+		if m.Kind() == reflect.Float32 { // the precision of the column
+			return float32(m.Float()) == float32(df)
+		}
+		return m.Float() == df
+
+	case reflect.String:
+		return d.Kind() == reflect.String && m.String() == d.String()
+	}
+	return reflect.DeepEqual(model, db)
+}
+
+// integer returns the value of an integer or a boolean like int64.
+func integer(v reflect.Value) (int64, bool) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return 1, true
+		}
+		return 0, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), true
+	}
+	return 0, false
+}
@@ -0,0 +1,70 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+var testEnum = EnumTable{Name: "sex", Values: []EnumValue{
+	{ID: 0, Name: "female"}, {ID: 1, Name: "male"}, {ID: 2, Name: "other"},
+}}
+
+// dbRows returns the rows of a table of enumeration without attributes.
+func dbRows(names map[int64]string) map[int64]EnumValue {
+	rows := make(map[int64]EnumValue, len(names))
+	for id, name := range names {
+		rows[id] = EnumValue{ID: id, Name: name}
+	}
+	return rows
+}
+
+func TestDiffEnum(t *testing.T) {
+	r := diffEnum(testEnum, dbRows(map[int64]string{0: "female", 1: "Male", 5: "foo", 3: "bar"}))
+
+	if got := fmt.Sprint(r.Missing, r.Changed, r.Extra); got != "[2] [1] [3 5]" {
+		t.Errorf("got missing, changed and extra rows %s", got)
+	}
+	if got, want := r.String(), `enumeration "sex": missing [2], changed [1], extra [3 5]`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if r = diffEnum(testEnum, dbRows(map[int64]string{0: "female", 1: "male", 2: "other"})); !r.isEmpty() {
+		t.Errorf("expected to get no differences, got %v", r)
+	}
+
+	// Extra attributes, with the types returned by the drivers
+	status := EnumTable{Name: "status", Columns: []string{"label", "sort_order", "active"},
+		Values: []EnumValue{
+			{ID: 1, Name: "draft", Attrs: []interface{}{"Draft", 2, true}},
+			{ID: 2, Name: "done", Attrs: []interface{}{"Done", 1, false}},
+		}}
+	r = diffEnum(status, map[int64]EnumValue{
+		1: {ID: 1, Name: "draft", Attrs: []interface{}{[]byte("Draft"), int64(2), int64(1)}},
+		2: {ID: 2, Name: "done", Attrs: []interface{}{"Finished", int64(1), false}},
+	})
+	if got := fmt.Sprint(r.Missing, r.Changed, r.Extra); got != "[] [2] []" {
+		t.Errorf("attributes: got missing, changed and extra rows %s", got)
+	}
+}
+
+func TestSyncEnums(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	for _, verifyOnly := range []bool{true, false} {
+		reports, err := SyncEnums(context.Background(), db, Postgres, []EnumTable{testEnum}, verifyOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reports) != 1 || len(reports[0].Missing) != 3 {
+			t.Errorf("verifyOnly=%v: got %v", verifyOnly, reports)
+		}
+	}
+}
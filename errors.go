@@ -0,0 +1,286 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Kinds of constraint violations, to be checked through errors.Is.
+var (
+	ErrUniqueViolation     = errors.New("unique constraint violation")
+	ErrForeignKeyViolation = errors.New("foreign key constraint violation")
+	ErrNotNullViolation    = errors.New("not null constraint violation")
+	ErrCheckViolation      = errors.New("check constraint violation")
+)
+
+// A ConstraintError represents a constraint violation returned by a driver.
+// The names of constraint, table and columns are set when they are known.
+type ConstraintError struct {
+	Kind       error // ErrUniqueViolation, ErrForeignKeyViolation, ...
+	Constraint string
+	Table      string
+	Columns    []string
+
+	Err error // returned by the driver
+}
+
+func (e *ConstraintError) Error() string {
+	msg := e.Kind.Error()
+	if e.Constraint != "" {
+		msg += fmt.Sprintf(" %q", e.Constraint)
+	}
+	if e.Table != "" {
+		msg += fmt.Sprintf(" in table %q", e.Table)
+	}
+	if len(e.Columns) != 0 {
+		msg += fmt.Sprintf(" (%s)", strings.Join(e.Columns, ", "))
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+// Is reports whether the kind of violation is target.
+func (e *ConstraintError) Is(target error) bool { return e.Kind == target }
+
+func (e *ConstraintError) Unwrap() error { return e.Err }
+
+// ClassifyError returns a *ConstraintError when err, returned by the driver
+// of the given engine, is due to a constraint violation; else, err is
+// returned without changes.
+//
+// The table and columns are got from the constraints registered through
+// RegisterConstraints when the driver does not return them.
+func ClassifyError(eng Engine, err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *ConstraintError
+	if errors.As(err, &e) {
+		return err
+	}
+
+	code := errorCode(eng, err)
+	msg := err.Error()
+	e = &ConstraintError{Err: err}
+
+	switch eng {
+	case Postgres:
+		switch code {
+		case "23505":
+			e.Kind = ErrUniqueViolation
+		case "23503":
+			e.Kind = ErrForeignKeyViolation
+		case "23502":
+			e.Kind = ErrNotNullViolation
+		case "23514":
+			e.Kind = ErrCheckViolation
+		default:
+			return err
+		}
+		// github.com/lib/pq, github.com/jackc/pgx
+		e.Constraint = stringField(err, "Constraint", "ConstraintName")
+		e.Table = stringField(err, "Table", "TableName")
+		if col := stringField(err, "Column", "ColumnName"); col != "" {
+			e.Columns = []string{col}
+		}
+		if e.Constraint == "" {
+			e.Constraint = submatch(rePostgresConstraint, msg)
+		}
+		if len(e.Columns) == 0 && e.Kind == ErrNotNullViolation {
+			if col := submatch(rePostgresColumn, msg); col != "" {
+				e.Columns = []string{col}
+			}
+		}
+
+	case MySQL:
+		switch code {
+		case "1062", "1586":
+			e.Kind = ErrUniqueViolation
+			e.Constraint = submatch(reMySQLKey, msg)
+		case "1451", "1452", "1216", "1217":
+			e.Kind = ErrForeignKeyViolation
+			e.Table = submatch(reMySQLFKTable, msg)
+			e.Constraint = submatch(reMySQLFKName, msg)
+		case "1048", "1364":
+			e.Kind = ErrNotNullViolation
+			if col := submatch(reMySQLColumn, msg); col != "" {
+				e.Columns = []string{col}
+			}
+		case "3819":
+			e.Kind = ErrCheckViolation
+			e.Constraint = submatch(reMySQLCheck, msg)
+		default:
+			return err
+		}
+		// Since MySQL 8.0.19, the key is prefixed by the table.
+		if i := strings.IndexByte(e.Constraint, '.'); i != -1 && e.Kind == ErrUniqueViolation {
+			e.Table, e.Constraint = e.Constraint[:i], e.Constraint[i+1:]
+		}
+
+	case SQLite:
+		switch {
+		case code == "2067" || code == "1555" || strings.Contains(msg, "UNIQUE constraint failed"):
+			e.Kind = ErrUniqueViolation
+		case code == "787" || strings.Contains(msg, "FOREIGN KEY constraint failed"):
+			e.Kind = ErrForeignKeyViolation
+		case code == "1299" || strings.Contains(msg, "NOT NULL constraint failed"):
+			e.Kind = ErrNotNullViolation
+		case code == "275" || strings.Contains(msg, "CHECK constraint failed"):
+			e.Kind = ErrCheckViolation
+		default:
+			return err
+		}
+
+		// "UNIQUE constraint failed: table.col1, table.col2"
+		if list := submatch(reSQLiteColumns, msg); list != "" {
+			if e.Kind == ErrCheckViolation {
+				e.Constraint = list
+			} else {
+				for _, v := range strings.Split(list, ", ") {
+					if i := strings.IndexByte(v, '.'); i != -1 {
+						e.Table, v = v[:i], v[i+1:]
+					}
+					e.Columns = append(e.Columns, v)
+				}
+			}
+		}
+
+	default:
+		return err
+	}
+
+	if c, ok := lookupConstraint(eng, e.Table, e.Constraint); ok {
+		if e.Table == "" {
+			e.Table = c.Table
+		}
+		if len(e.Columns) == 0 {
+			e.Columns = c.Columns
+		}
+	}
+	return e
+}
+
+var (
+	rePostgresConstraint = regexp.MustCompile(`constraint "([^"]+)"`)
+	rePostgresColumn     = regexp.MustCompile(`column "([^"]+)"`)
+
+	reMySQLKey     = regexp.MustCompile(`for key '([^']+)'`)
+	reMySQLFKTable = regexp.MustCompile("fails \\(`[^`]+`\\.`([^`]+)`")
+	reMySQLFKName  = regexp.MustCompile("CONSTRAINT `([^`]+)`")
+	reMySQLColumn  = regexp.MustCompile(`(?:Column|Field) '([^']+)'`)
+	reMySQLCheck   = regexp.MustCompile(`Check constraint '([^']+)'`)
+
+	reSQLiteColumns = regexp.MustCompile(`constraint failed: (.+)$`)
+)
+
+// submatch returns the first submatch of re in s.
+func submatch(re *regexp.Regexp, s string) string {
+	if m := re.FindStringSubmatch(s); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// stringField returns the value of the first field found with a name in
+// names, in the error or in the errors which it wraps.
+func stringField(err error, names ...string) string {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		v := reflect.ValueOf(e)
+		for v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+
+		for _, name := range names {
+			if f := v.FieldByName(name); f.Kind() == reflect.String && f.String() != "" {
+				return f.String()
+			}
+		}
+	}
+	return ""
+}
+
+// * * *
+
+// A Constraint represents a constraint or unique index defined in the model.
+type Constraint struct {
+	Name    string
+	Kind    error // ErrUniqueViolation, ErrForeignKeyViolation, ...
+	Table   string
+	Columns []string
+}
+
+var (
+	constraints   = make(map[Engine]map[string][]Constraint) // by name
+	constraintsMu sync.RWMutex
+)
+
+// RegisterConstraints registers the constraints created in the given engine,
+// to get the table and columns of the constraint violations.
+// It is to be called from the Go code generated.
+func RegisterConstraints(eng Engine, cons ...Constraint) {
+	constraintsMu.Lock()
+	defer constraintsMu.Unlock()
+
+	if constraints[eng] == nil {
+		constraints[eng] = make(map[string][]Constraint, len(cons))
+	}
+	for _, v := range cons {
+		name := strings.ToLower(v.Name)
+		constraints[eng][name] = append(constraints[eng][name], v)
+	}
+}
+
+// lookupConstraint returns the constraint registered with the given name.
+// Since a name could be not unique in all database, like "PRIMARY" in MySQL,
+// the table has to be set in that case.
+func lookupConstraint(eng Engine, table, name string) (Constraint, bool) {
+	constraintsMu.RLock()
+	defer constraintsMu.RUnlock()
+
+	var found []Constraint
+	for _, v := range constraints[eng][strings.ToLower(name)] {
+		if table == "" || strings.EqualFold(table, v.Table) {
+			found = append(found, v)
+		}
+	}
+	if len(found) != 1 {
+		return Constraint{}, false
+	}
+	return found[0], true
+}
+
+// A LengthError represents a string longer than the size of its column.
+type LengthError struct {
+	Table  string
+	Column string
+	Size   int
+	Length int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("column %q in table %q: length %d greater than size %d",
+		e.Column, e.Table, e.Length, e.Size)
+}
+
+// CheckLength returns a *LengthError if the value has more characters than
+// the size of the column.
+// It is to be called from the Go code generated.
+func CheckLength(table, column, value string, size int) error {
+	if n := utf8.RuneCountInString(value); n > size {
+		return &LengthError{Table: table, Column: column, Size: size, Length: n}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type pgError struct {
+	Code           string
+	ConstraintName string
+	TableName      string
+}
+
+func (e *pgError) Error() string    { return "ERROR: " + e.Code }
+func (e *pgError) SQLState() string { return e.Code }
+
+func TestClassifyError(t *testing.T) {
+	RegisterConstraints(MySQL,
+		Constraint{Name: "PRIMARY", Kind: ErrUniqueViolation, Table: "foo", Columns: []string{"id"}},
+		Constraint{Name: "PRIMARY", Kind: ErrUniqueViolation, Table: "bar", Columns: []string{"id"}},
+		Constraint{Name: "code", Kind: ErrUniqueViolation, Table: "bar", Columns: []string{"code"}},
+	)
+
+	tests := []struct {
+		eng     Engine
+		err     error
+		kind    error
+		table   string
+		columns []string
+	}{
+		{Postgres, &pgError{"23505", "bar_pkey", "bar"}, ErrUniqueViolation, "bar", nil},
+		{Postgres, &pqError{"23503"}, ErrForeignKeyViolation, "", nil},
+		{MySQL, &mysqlError{1062, "Duplicate entry '1' for key 'bar.PRIMARY'"},
+			ErrUniqueViolation, "bar", []string{"id"}},
+		{MySQL, &mysqlError{1062, "Duplicate entry 'a' for key 'code'"},
+			ErrUniqueViolation, "bar", []string{"code"}},
+		{MySQL, &mysqlError{1048, "Column 'name' cannot be null"},
+			ErrNotNullViolation, "", []string{"name"}},
+		{MySQL, &mysqlError{1452, "Cannot add or update a child row: a foreign key " +
+			"constraint fails (`db`.`chapter`, CONSTRAINT `chapter_ibfk_1` FOREIGN KEY " +
+			"(`book_fk`) REFERENCES `book` (`book_id`))"},
+			ErrForeignKeyViolation, "chapter", nil},
+		{SQLite, errors.New("UNIQUE constraint failed: foo.a, foo.b"),
+			ErrUniqueViolation, "foo", []string{"a", "b"}},
+		{SQLite, sqliteError{19, 1299}, ErrNotNullViolation, "", nil},
+		{SQLite, errors.New("CHECK constraint failed: ck_foo"), ErrCheckViolation, "", nil},
+	}
+
+	for _, tt := range tests {
+		err := ClassifyError(tt.eng, tt.err)
+
+		var e *ConstraintError
+		if !errors.As(err, &e) {
+			t.Errorf("%s: %v: expected to get a *ConstraintError", tt.eng, tt.err)
+			continue
+		}
+		if !errors.Is(err, tt.kind) || errors.Unwrap(err) != tt.err {
+			t.Errorf("%s: %v: got kind %v", tt.eng, tt.err, e.Kind)
+		}
+		if e.Table != tt.table || strings.Join(e.Columns, ",") != strings.Join(tt.columns, ",") {
+			t.Errorf("%s: %v: got table %q and columns %q, want %q and %q",
+				tt.eng, tt.err, e.Table, e.Columns, tt.table, tt.columns)
+		}
+	}
+
+	err := &mysqlError{1213, "Deadlock found"}
+	if ClassifyError(MySQL, err) != error(err) {
+		t.Error("expected to get the same error when it is not a constraint violation")
+	}
+}
+
+func TestCheckLength(t *testing.T) {
+	if err := CheckLength("t", "c", "ñandú", 5); err != nil {
+		t.Errorf("expected to count the characters, got %v", err)
+	}
+
+	var e *LengthError
+	if err := CheckLength("t", "c", "abcdef", 5); !errors.As(err, &e) || e.Length != 6 {
+		t.Errorf("got %v, want a *LengthError", err)
+	}
+}
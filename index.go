@@ -0,0 +1,248 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// indexMethods are the methods of index supported by every engine.
+var indexMethods = map[Engine][]string{
+	Postgres: {"btree", "hash", "gin", "gist", "brin"},
+	MySQL:    {"btree", "hash"},
+}
+
+// compoIndex represents an index defined at table level.
+type compoIndex struct {
+	isUnique bool
+	index    []string
+	table    *table
+
+	name    string
+	desc    map[string]bool // columns in descending order
+	prefix  map[string]int  // MySQL: length of the prefix of the columns
+	exprs   []string
+	where   string // to create a partial index
+	method  string
+	include []string
+}
+
+// Index creates an index on a group of columns.
+func (t *table) Index(unique bool, columns ...string) *compoIndex {
+	t.existColumns("Index", columns)
+
+	idx := &compoIndex{isUnique: unique, index: columns, table: t}
+	t.index = append(t.index, idx)
+	return idx
+}
+
+// Name sets the name of the index, instead of "idx_<table>__m<N>".
+func (i *compoIndex) Name(name string) *compoIndex {
+	what := i.what(fmt.Sprintf("Name(%q)", name))
+
+	if name == "" || strings.ContainsAny(name, " \t\n\"'`{}") {
+		log.Fatalf("%s: invalid name for an index", what)
+	}
+	checkNameLen(i.table.meta, what, name)
+	for _, t := range i.table.meta.tables {
+		for _, v := range t.index {
+			if v != i && v.defaultName() == name {
+				log.Fatalf("%s: the name is already used in table %q", what, t.Name)
+			}
+		}
+	}
+	i.name = name
+	return i
+}
+
+// Desc sets the descending order for columns of the index.
+func (i *compoIndex) Desc(columns ...string) *compoIndex {
+	i.hasColumns("Desc", columns)
+	if i.desc == nil {
+		i.desc = make(map[string]bool)
+	}
+	for _, v := range columns {
+		i.desc[v] = true
+	}
+	return i
+}
+
+// Prefix sets the length of the prefix of a column of type String or Binary,
+// to use in MySQL.
+func (i *compoIndex) Prefix(column string, n int) *compoIndex {
+	what := i.what("Prefix")
+	i.hasColumns("Prefix", []string{column})
+
+	if c := i.table.column(column); c.type_ != String && c.type_ != Binary {
+		log.Fatalf("%s: column %q has not type String or Binary", what, column)
+	}
+	if n <= 0 {
+		log.Fatalf("%s: wrong length %d", what, n)
+	}
+	if i.prefix == nil {
+		i.prefix = make(map[string]int)
+	}
+	i.prefix[column] = n
+	return i
+}
+
+// Expr adds SQL expressions to the index, after its columns.
+func (i *compoIndex) Expr(expr ...string) *compoIndex {
+	for _, v := range expr {
+		i.checkSQL("Expr", v)
+	}
+	i.exprs = append(i.exprs, expr...)
+	return i
+}
+
+// Where sets the predicate of a partial index, in Postgres and SQLite.
+func (i *compoIndex) Where(predicate string) *compoIndex {
+	i.checkSQL("Where", predicate)
+	i.supported("Where", Postgres, SQLite)
+	i.where = predicate
+	return i
+}
+
+// Using sets the method of the index: btree, hash, gin, gist or brin in
+// Postgres, and btree or hash in MySQL.
+func (i *compoIndex) Using(method string) *compoIndex {
+	what := i.what(fmt.Sprintf("Using(%q)", method))
+	method = strings.ToLower(method)
+
+	for _, eng := range i.table.meta.engines {
+		found := false
+		for _, v := range indexMethods[eng] {
+			if v == method {
+				found = true
+				break
+			}
+		}
+		if !found {
+			log.Fatalf("%s: method not supported in %s", what, eng)
+		}
+	}
+	i.method = method
+	return i
+}
+
+// Include adds columns which are not part of the key of the index, in Postgres.
+func (i *compoIndex) Include(columns ...string) *compoIndex {
+	i.table.existColumns("Include", columns)
+	i.supported("Include", Postgres)
+	i.include = append(i.include, columns...)
+	return i
+}
+
+// * * *
+
+// what returns the description of the index and the function, for the errors.
+func (i *compoIndex) what(funcName string) string {
+	return fmt.Sprintf("table %q: index %q: %s", i.table.Name, i.defaultName(), funcName)
+}
+
+// hasColumns checks if the index has the columns.
+func (i *compoIndex) hasColumns(funcName string, columns []string) {
+	for _, c := range columns {
+		found := false
+		for _, v := range i.index {
+			if v == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			log.Fatalf("%s: column %q is not in the index", i.what(funcName), c)
+		}
+	}
+}
+
+// checkSQL checks that the SQL code can be used into the templates.
+func (i *compoIndex) checkSQL(funcName, sql string) {
+	if sql == "" || strings.Contains(sql, "{{") {
+		log.Fatalf("%s: invalid SQL %q", i.what(funcName), sql)
+	}
+}
+
+// supported checks that the metadata only uses the given engines.
+func (i *compoIndex) supported(funcName string, engines ...Engine) {
+L:
+	for _, eng := range i.table.meta.engines {
+		for _, v := range engines {
+			if v == eng {
+				continue L
+			}
+		}
+		log.Fatalf("%s: not supported in %s", i.what(funcName), eng)
+	}
+}
+
+// defaultName returns the name of the index, which is "idx_<table>__m<N>"
+// when it is not set.
+func (i *compoIndex) defaultName() string {
+	if i.name != "" {
+		return i.name
+	}
+	for n, v := range i.table.index {
+		if v == i {
+			return fmt.Sprintf("idx_%s__m%d", i.table.Name, n+1)
+		}
+	}
+	return ""
+}
+
+// isFull reports whether the index is built on all rows, only by columns.
+func (i *compoIndex) isFull() bool {
+	return i.where == "" && len(i.exprs) == 0
+}
+
+// sql returns the statement to create the index.
+func (i *compoIndex) sql() string {
+	t := i.table
+	if len(i.index) == 0 && len(i.exprs) == 0 {
+		log.Fatalf("table %q: index %q: no columns", t.Name, i.defaultName())
+	}
+
+	var keys []string
+	for _, v := range i.index {
+		key := t.column(v).indexName()
+		if n := i.prefix[v]; n != 0 {
+			key = fmt.Sprintf("%s{{if eq .Engine \"MySQL\"}}(%d){{end}}", v, n)
+		}
+		if i.desc[v] {
+			key += " DESC"
+		}
+		keys = append(keys, key)
+	}
+	for _, v := range i.exprs {
+		keys = append(keys, "("+v+")")
+	}
+
+	unique := ""
+	if i.isUnique {
+		unique = "UNIQUE "
+	}
+	using, mysqlUsing := "", ""
+	if i.method != "" {
+		using = fmt.Sprintf("{{if eq .Engine \"Postgres\"}} USING %s{{end}}", i.method)
+		mysqlUsing = fmt.Sprintf("{{if eq .Engine \"MySQL\"}} USING %s{{end}}",
+			strings.ToUpper(i.method))
+	}
+	include := ""
+	if len(i.include) != 0 {
+		include = fmt.Sprintf(" INCLUDE (%s)", strings.Join(i.include, ", "))
+	}
+	where := ""
+	if i.where != "" {
+		where = " WHERE " + i.where
+	}
+
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s%s (%s)%s%s%s;\n",
+		unique, sqlEngineName(i.defaultName()), t.sqlName, using,
+		strings.Join(keys, ", "), mysqlUsing, include, where)
+}
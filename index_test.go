@@ -0,0 +1,43 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import "testing"
+
+func TestIndex(t *testing.T) {
+	resetColumnsErr(t)
+
+	md := Metadata("model", Postgres)
+	tb := Table("doc", md,
+		Column("id", Int).PrimaryKey(),
+		Column("title", String),
+		Column("tags", String),
+		Column("deleted", Bool),
+	)
+	idx1 := tb.Index(true, "title").Where("NOT deleted").Include("id")
+	idx2 := tb.Index(false, "tags").Name("idx_doc_tags").Using("GIN")
+	idx3 := tb.Index(false, "id", "title").Desc("id").Prefix("title", 20).Expr("lower(tags)")
+
+	tests := []struct{ got, want string }{
+		{idx1.sql(), "CREATE UNIQUE INDEX idx_doc__m1 ON doc (title) INCLUDE (id) WHERE NOT deleted;\n"},
+		{idx2.sql(), `CREATE INDEX idx_doc_tags ON doc{{if eq .Engine "Postgres"}} USING gin{{end}} (tags)` +
+			`{{if eq .Engine "MySQL"}} USING GIN{{end}};` + "\n"},
+		{idx3.sql(), `CREATE INDEX idx_doc__m3 ON doc (id DESC, title{{if eq .Engine "MySQL"}}(20){{end}}, (lower(tags)));` + "\n"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %s\nwant %s", tt.got, tt.want)
+		}
+	}
+
+	if tb.isUniqueKey([]string{"title"}) {
+		t.Error("a partial index is not an unique key")
+	}
+	if !tb.inIndex("title") || !tb.inIndex("tags") {
+		t.Error("expected columns in indexes without prefix")
+	}
+}
@@ -19,7 +19,6 @@ import (
 	"strings"
 	"text/template"
 	"time"
-	"unicode"
 )
 
 const (
@@ -35,16 +34,32 @@ type metadata struct {
 
 	posQueries int
 
-	engines []Engine
-	tables  []*table
+	engines     []Engine
+	tables      []*table
+	nativeEnums []*nativeEnum
+	mixins      []*mixin // embedded mixins
 
 	goCode    []string
+	goImports map[string]bool
 	sqlCreate []string
 	sqlDrop   []string
 	sqlTest   []string
-	sqlInsert []string
+
+	// Statements for the Go types
+	sqlInsert  []string
+	sqlSelect  []string
+	sqlUpdate  []string
+	sqlDelete  []string
+	sqlExists  []string
+	sqlUpsert  map[Engine][]string
+	sqlRelated []string
 
 	pkgName string
+
+	// For the names and tags of the Go types
+	naming    func(name string) string
+	omitEmpty bool
+	rename    bool // to rename the Go identifiers which collide
 }
 
 // Metadata returns a new metadata.
@@ -59,7 +74,13 @@ func Metadata(packageName string, eng ...Engine) *metadata {
 		}
 	}
 
-	return &metadata{engines: eng, pkgName: packageName}
+	return &metadata{
+		engines:   eng,
+		pkgName:   packageName,
+		naming:    TitleName,
+		goImports: make(map[string]bool),
+		sqlUpsert: make(map[Engine][]string),
+	}
 }
 
 // * * *
@@ -74,10 +95,13 @@ func (md *metadata) Create() *metadata {
 		return strings.Repeat(" ", maxLen-nameLen)
 	}
 
+	md.checkNames()
+	md.checkConstraintNames()
+
 	md.goCode = append(md.goCode, fmt.Sprintf("%s\npackage %s\n", _HEADER_EDIT, md.pkgName))
 
 	md.goCode = append(md.goCode, "import (\n\"database/sql\"\n")
-	md.goCode = append(md.goCode, "") // Could add another import
+	md.goCode = append(md.goCode, "") // To add another imports
 	md.goCode = append(md.goCode, "\n\"github.com/kless/modsql\"\n)")
 
 	md.goCode = append(md.goCode, "\n// == EDIT\n")
@@ -88,30 +112,37 @@ func (md *metadata) Create() *metadata {
 		md.goCode = append(md.goCode, "const ENGINE = modsql."+v.String()+"\n")
 	}
 
-	md.goCode = append(md.goCode, `
-// * * *
-
-var Insert = modsql.NewStatements(map[int]string{
-`)
+	md.goCode = append(md.goCode, "\n// * * *\n")
 
 	// To add all queries
 	md.posQueries = len(md.goCode)
 	md.goCode = append(md.goCode, "")
 
-	md.goCode = append(md.goCode, `,
-})
-
-`)
-
 	md.sqlCreate = append(md.sqlCreate,
 		fmt.Sprintf("%s\n%s", _CONSTRAINT, _HEADER))
 
 	md.sqlDrop = append(md.sqlDrop, _HEADER)
 	md.sqlDrop = append(md.sqlDrop, "{{.MySQLDrop0}}")
 
-	useTime := false
+	for _, e := range md.nativeEnums {
+		// Postgres creates a type for every table.
+		for _, t := range md.tables {
+			if t.Name == e.Name {
+				log.Fatalf("native enumeration %q: there is a table with the same name", e.Name)
+			}
+		}
+		md.sqlCreate = append(md.sqlCreate, e.sqlCreate())
+		md.goCode = append(md.goCode, md.genNativeEnum(e))
+	}
+
+	if md.hasTimestamps() {
+		md.sqlCreate = append(md.sqlCreate, sqlUpdatedAtFunc())
+	}
+
 	iTable := 0 // to differenciate from tables for enums
 
+	generated := make(map[*mixin]bool) // Go types of the embedded mixins
+
 	for _, table := range md.tables {
 		// == Get the length of largest field
 		fieldMaxLen := 2 // minimum length (id)
@@ -124,94 +155,68 @@ var Insert = modsql.NewStatements(map[int]string{
 		// ==
 
 		if !table.isEnum {
+			md.goImports["strings"] = true
 			md.goCode = append(md.goCode,
-				fmt.Sprintf("\ntype %s struct {\n", strings.Title(table.Name)))
-		} else {
-			md.goCode = append(md.goCode, "\n// "+table.Name+"\nconst(\n")
+				fmt.Sprintf("\n%stype %s struct {\n", goComment(table.comment), table.typeName()))
 		}
 
 		md.sqlCreate = append(md.sqlCreate,
-			fmt.Sprintf("\nCREATE TABLE %s (", table.sqlName))
+			fmt.Sprintf("\nCREATE TABLE %s%s (", table.sqlName, table.sqlTableComment()))
 		md.sqlDrop = append(md.sqlDrop,
 			fmt.Sprintf("\nDROP TABLE %s{{.PostgresDrop}};", table.sqlName))
 
 		columnIndex := make([]string, 0)
-		columnNames := make([]string, 0)
-		columnValues := make([]string, 0)
+		mixinCode := ""
 
 		for iCol, col := range table.Columns {
 			extra := ""
 
-			//if !useTime && (col.type_ == Duration || col.type_ == DateTime) {
-			if !useTime && col.type_ == DateTime {
-				useTime = true
+			//if col.type_ == Duration || col.type_ == DateTime {
+			if col.type_ == DateTime && !col.null {
+				md.goImports["time"] = true
 			}
 
-			if !table.isEnum {
-				type_ := col.type_.goString()
-
-				md.goCode = append(md.goCode,
-					fmt.Sprintf("%s %s\n", strings.Title(col.Name), type_))
-				columnNames = append(columnNames, col.Name)
-				columnValues = append(columnValues, type_)
-			} else if iCol == 0 {
-				name := table.Name
+			if col.mixin != nil {
+				// The field of the embedded type is added at its first column.
+				if iCol == 0 || table.Columns[iCol-1].mixin != col.mixin {
+					md.goCode = append(md.goCode, col.mixin.typeName(md)+"\n")
 
-				// Get the first part of the table name; until '_' or letter is upper
-				for iName, letter := range table.Name[1:] {
-					if unicode.IsUpper(letter) || letter == '_' {
-						name = table.Name[:iName+1]
-						break
-					}
-				}
-				name = strings.ToUpper(name) + "_"
-
-				for iData, vData := range table.data {
-					if iData == 0 {
-						iota_ := "iota"
-
-						if table.startEnum != 0 {
-							iota_ += " + " + strconv.Itoa(table.startEnum)
-						}
-						md.goCode = append(md.goCode, fmt.Sprintf("%s = %s\n",
-							name+strings.ToUpper(vData[1].(string)), iota_))
-					} else {
-						md.goCode = append(md.goCode, fmt.Sprintf("%s\n",
-							name+strings.ToUpper(vData[1].(string))))
+					if !generated[col.mixin] {
+						generated[col.mixin] = true
+						mixinCode += md.genMixin(table, col.mixin)
 					}
 				}
+			} else if !table.isEnum {
+				md.goCode = append(md.goCode, fmt.Sprintf("%s%s %s %s\n", goComment(col.comment),
+					table.fieldName(col.Name), table.goType(&col), table.fieldTags(&col)))
 			}
 
 			// == MySQL: Limit the key length in TEXT or BLOB columns
 			sqlString := col.type_.tmplAction()
 
-			if col.type_ == String || col.type_ == Binary {
+			if col.enum != nil {
+				sqlString = col.enum.sqlType(col.Name)
+			} else if s := col.sqlSizeType(); s != "" {
+				sqlString = s
+			} else if col.type_ == String || col.type_ == Binary {
 				limit := false
 
 				if col.cons&primaryKey != 0 || col.cons&uniqueCons != 0 {
 					limit = true
 				}
-
-				if !limit {
-					for _, v := range table.uniqueCons {
-						if col.Name == v {
-							limit = true
-							break
-						}
-					}
+				// The indexes need the length of the prefix.
+				if col.prefix == 0 && (col.index != 0 || table.inIndex(col.Name)) {
+					limit = true
 				}
-				if !limit {
-					for _, v := range table.pkCons {
-						if col.Name == v {
-							limit = true
-							break
-						}
-					}
+				// The types TEXT and BLOB can not have a value by default.
+				if col.defaultValue != nil || col.hasDefaultExpr() {
+					limit = true
 				}
+
 				if !limit {
 				L:
-					for _, fk := range table.fkCons {
-						for _, v := range fk.src {
+					for _, c := range table.constraints() {
+						for _, v := range c.columns {
 							if col.Name == v {
 								limit = true
 								break L
@@ -221,7 +226,11 @@ var Insert = modsql.NewStatements(map[int]string{
 				}
 
 				if limit {
-					sqlString = "{{.StringLimit}}"
+					if col.type_ == String {
+						sqlString = "{{.StringLimit}}"
+					} else {
+						sqlString = "{{.BinaryLimit}}"
+					}
 				}
 			}
 			// ==
@@ -240,25 +249,20 @@ var Insert = modsql.NewStatements(map[int]string{
 				extra += fmt.Sprintf(" REFERENCES %s(%s)", quoteSQL(col.fkTable), col.fkColumn)
 			}
 
-			if col.defaultValue != nil {
-				extra += " DEFAULT "
-
-				switch t := col.defaultValue.(type) {
-				case bool:
-					extra += boolAction(t)
-				//case string: extra += fmt.Sprintf("'%s'", t)
-				default:
-					extra += fmt.Sprintf("%v", t)
-				}
+			extra += col.sqlDefault()
+			if col.auto {
+				extra += table.sqlColumnExtra(&col)
 			}
+			extra += col.sqlColumnComment()
 			if col.index != 0 {
 				unique := ""
 				if col.index == uniqIndex {
 					unique = "UNIQUE "
 				}
 				columnIndex = append(columnIndex,
-					fmt.Sprintf("CREATE %sINDEX idx_%s_%s ON %s (%s);\n",
-						unique, table.Name, col.Name, table.sqlName, col.Name))
+					fmt.Sprintf("CREATE %sINDEX %s ON %s (%s);\n", unique,
+						sqlEngineName(fmt.Sprintf("idx_%s_%s", table.Name, col.Name)),
+						table.sqlName, col.indexName()))
 			}
 
 			md.sqlCreate = append(md.sqlCreate, extra)
@@ -267,52 +271,40 @@ var Insert = modsql.NewStatements(map[int]string{
 			if iCol+1 == len(table.Columns) {
 				var cons []string
 
-				if len(table.uniqueCons) != 0 {
-					cons = append(cons, fmt.Sprintf("UNIQUE (%s)",
-						strings.Join(table.uniqueCons, ", ")))
-				}
-				if len(table.pkCons) != 0 {
-					cons = append(cons, fmt.Sprintf("PRIMARY KEY (%s)",
-						strings.Join(table.pkCons, ", ")))
-				}
-				for _, fk := range table.fkCons {
-					cons = append(cons, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
-						strings.Join(fk.src, ", "), quoteSQL(fk.table),
-						strings.Join(fk.dst, ", ")))
+				for _, c := range table.constraints() {
+					cons = append(cons, c.sql())
 				}
 
 				if len(cons) != 0 {
 					md.sqlCreate = append(md.sqlCreate, ",\n\n\t"+strings.Join(cons, ",\n\t"))
 				}
-				md.sqlCreate = append(md.sqlCreate, "\n);\n")
+				md.sqlCreate = append(md.sqlCreate, "\n)"+table.sqlTableOptions()+";\n",
+					table.sqlCommentOn())
 				if !table.isEnum {
-					md.goCode = append(md.goCode, "}\n")
+					md.goCode = append(md.goCode, "}\n"+mixinCode)
 
 					md.goCode = append(md.goCode,
-						md.genInsertForType(iTable, table.Name, columnNames, columnValues),
+						md.genInsertForType(iTable, table),
+						md.genUpsertForType(iTable, table),
+						md.genScanForType(table),
+						md.genPKForType(iTable, table),
+						md.genValidate(table),
 					)
 					iTable++
 				} else {
-					md.goCode = append(md.goCode, ")\n")
+					md.goCode = append(md.goCode, md.genEnum(table))
 				}
 
 				// Indexes
-				for i, v := range table.index {
-					name := fmt.Sprintf("_m%d", i+1)
-
-					unique := ""
-					if v.isUnique {
-						unique = "UNIQUE "
-					}
-
-					columnIndex = append(columnIndex,
-						fmt.Sprintf("CREATE %sINDEX idx_%s_%s ON %s (%s);\n",
-							unique, table.Name, name, table.sqlName,
-							strings.Join(v.index, ", ")))
+				for _, v := range table.index {
+					columnIndex = append(columnIndex, v.sql())
 				}
 				if len(columnIndex) != 0 {
 					md.sqlCreate = append(md.sqlCreate, columnIndex...)
 				}
+				if table.timestamps {
+					md.sqlCreate = append(md.sqlCreate, table.sqlTrigger())
+				}
 
 			} else {
 				md.sqlCreate = append(md.sqlCreate, ",")
@@ -320,11 +312,20 @@ var Insert = modsql.NewStatements(map[int]string{
 		}
 	}
 
-	if useTime {
-		md.goCode[2] = "\"time\"\n"
+	md.goCode = append(md.goCode, md.genRelations(), md.genSyncEnums())
+
+	for k := range md.goImports {
+		md.goCode[2] += strconv.Quote(k) + "\n"
 	}
 
-	md.goCode[md.posQueries] = strings.Join(md.sqlInsert, ",\n")
+	md.goCode[md.posQueries] = genStatements("Insert", md.sqlInsert) +
+		genStatements("SelectByPK", md.sqlSelect) +
+		genStatements("Update", md.sqlUpdate) +
+		genStatements("Delete", md.sqlDelete) +
+		genStatements("Exists", md.sqlExists) +
+		md.genStatementsByEngine("Upsert", md.sqlUpsert) +
+		genStatements("SelectRelated", md.sqlRelated) +
+		md.genConstraints()
 
 	// == Insert
 	if md.useInsert {
@@ -334,6 +335,12 @@ var Insert = modsql.NewStatements(map[int]string{
 		md.sqlTest = append(md.sqlTest, _HEADER)
 		md.sqlTest = append(md.sqlTest, md.genInsert(true)...)
 	}
+	if md.hasTimestamps() {
+		md.sqlDrop = append(md.sqlDrop, sqlDropUpdatedAtFunc())
+	}
+	for i := len(md.nativeEnums) - 1; i >= 0; i-- {
+		md.sqlDrop = append(md.sqlDrop, md.nativeEnums[i].sqlDrop())
+	}
 	md.sqlDrop = append(md.sqlDrop, "{{.MySQLDrop1}}")
 	md.sqlDrop = append(md.sqlDrop, "\n\n")
 
@@ -483,7 +490,7 @@ func (md *metadata) genInsert(testdata bool) []string {
 		if len(data) != 0 {
 			var columns []string
 
-			for _, col := range table.Columns {
+			for _, col := range table.insertColumns() {
 				columns = append(columns, quoteSQL(col.Name))
 			}
 			for _, v := range data {
@@ -555,10 +562,13 @@ func formatSQL(v []interface{}) string {
 }
 
 // genInsertForType generate the SQL statement to insert data from a Go type.
-func (md *metadata) genInsertForType(idx int, name string, columns, values []string) string {
-	args := make([]string, len(columns))
+func (md *metadata) genInsertForType(idx int, t *table) string {
+	insertCols := t.insertColumns()
+	columns := make([]string, len(insertCols))
+	args := make([]string, len(insertCols))
 
-	for i, _ := range values {
+	for i, col := range insertCols {
+		columns[i] = col.Name
 		addColumn := true
 
 		/*switch v {
@@ -571,30 +581,73 @@ func (md *metadata) genInsertForType(idx int, name string, columns, values []str
 		}*/
 
 		if addColumn {
-			args[i] = "&t." + strings.Title(columns[i])
+			args[i] = t.fieldArg(&col)
 		}
 	}
 
 	tmplArgs := strings.Repeat("{P}, ", len(args))
 	md.sqlInsert = append(md.sqlInsert,
 		fmt.Sprintf("%d: \"INSERT INTO %s (%s) VALUES(%s)\"",
-			len(md.sqlInsert), quoteStatementSQL(name), strings.Join(columns, ", "),
+			len(md.sqlInsert), quoteStatementSQL(t.Name), strings.Join(columns, ", "),
 			tmplArgs[:len(tmplArgs)-2]),
 	)
 
-	name = strings.Title(name)
+	tableName := t.Name
+	name := t.typeName()
+	md.goImports["context"] = true
+
+	// The columns set by the database are not inserted.
+	argsFunc, batchColumns, code := "Args", fmt.Sprintf("new(%s).Columns()", name), ""
+	if len(insertCols) != len(t.Columns) {
+		allArgs := make([]string, len(t.Columns))
+		for i, col := range t.Columns {
+			allArgs[i] = t.fieldArg(&col)
+		}
+		quoted := make([]string, len(columns))
+		for i, v := range columns {
+			quoted[i] = strconv.Quote(v)
+		}
+
+		argsFunc = "insertArgs"
+		batchColumns = fmt.Sprintf("[]string{%s}", strings.Join(quoted, ", "))
+		code = fmt.Sprintf("// insertArgs returns the arguments to insert a row, without the columns\n"+
+			"// set by the database.\n"+
+			"func (t *%s) insertArgs() []interface{} {\n"+
+			"return []interface{}{%s}\n"+
+			"}\n\n", name, strings.Join(args, ", "))
+		args = allArgs
+	}
 
 	return fmt.Sprintf(
 		"func (t *%s) Args() []interface{} {\n"+
 			"return []interface{}{%s}\n"+
 			"}\n\n"+
 
-			"func (t *%s) StmtInsert() *sql.Stmt { return Insert.Stmt[%d] }",
+			"%[6]s"+
+
+			"func (t *%[3]s) StmtInsert() (*sql.Stmt, error) { return Insert.Get(%[4]d) }\n\n"+
+
+			"func (t *%[1]s) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {\n"+
+			"return Insert.ExecContext(ctx, q, %[4]d, t.%[7]s()...)\n"+
+			"}\n\n"+
+
+			"// BatchInsert%[1]s inserts several rows within a transaction.\n"+
+			"func BatchInsert%[1]s(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*%[1]s) error {\n"+
+			"args := make([][]interface{}, len(rows))\n"+
+			"for i, v := range rows {\n"+
+			"args[i] = v.%[7]s()\n"+
+			"}\n"+
+			"return modsql.BatchInsert(ctx, q, ENGINE, opts, %[5]q, %[8]s, args)\n"+
+			"}",
 
 		name,
 		strings.Join(args, ", "),
 		name,
 		idx,
+		tableName,
+		code,
+		argsFunc,
+		batchColumns,
 	)
 }
 
@@ -605,3 +658,912 @@ func (md *metadata) genInsertForType(idx int, name string, columns, values []str
 		return err
 	}
 }*/
+
+// genValidate generates the method to check the length of the strings whose
+// column has a size. It returns an empty string if there is no size.
+func (md *metadata) genValidate(t *table) string {
+	code := ""
+	for _, col := range t.Columns {
+		if col.size == 0 {
+			continue
+		}
+		code += fmt.Sprintf("if err := modsql.CheckLength(%q, %q, t.%s, %d); err != nil {\n"+
+			"return err\n}\n",
+			t.Name, col.Name, t.fieldName(col.Name), col.size)
+	}
+	if code == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("\n\n// Validate checks that the strings are not longer than the size of their column.\n"+
+		"func (t *%s) Validate() error {\n%sreturn nil\n}", t.typeName(), code)
+}
+
+// genScanForType generates the Go code to scan the columns of a query by its
+// name.
+func (md *metadata) genScanForType(t *table) string {
+	name := t.typeName()
+
+	columns := make([]string, len(t.Columns))
+	cases := make([]string, len(t.Columns))
+
+	for i, col := range t.Columns {
+		columns[i] = strconv.Quote(col.Name)
+		cases[i] = fmt.Sprintf("case %q:\ndest[i] = %s",
+			strings.ToLower(col.Name), t.fieldArg(&col))
+	}
+
+	return fmt.Sprintf(`
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *%[1]s) Columns() []string {
+	return []string{%[2]s}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *%[1]s) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		%[3]s
+		default:
+			v, err := modsql.UnknownColumn(%[4]q, col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *%[1]s) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}`,
+		name, strings.Join(columns, ", "), strings.Join(cases, "\n"), t.Name)
+}
+
+// genStatements generates the variable with the given name to handle the
+// statements.
+func genStatements(name string, stmts []string) string {
+	if len(stmts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\nvar %s = modsql.NewStatements(map[int]string{\n%s,\n})\n",
+		name, strings.Join(stmts, ",\n"))
+}
+
+// genConstraints generates the Go code to register the constraints and the
+// unique indexes of all tables, by the names given by every engine, so the
+// constraint violations can be related to the model.
+// In SQLite, only the check constraints are registered since it returns the
+// columns instead of the name for the rest.
+func (md *metadata) genConstraints() string {
+	type cons struct {
+		name    string
+		kind    string
+		table   string
+		columns []string
+	}
+
+	code := ""
+	for _, eng := range md.engines {
+		var list []cons
+
+		for _, t := range md.tables {
+			add := func(name, kind string, columns ...string) {
+				list = append(list, cons{name, kind, t.Name, columns})
+			}
+
+			// The constraints at table level have a name, which is used for
+			// the check constraints in SQLite.
+			for _, c := range t.constraints() {
+				name := c.nameFor(eng)
+
+				switch {
+				case c.kind == checkCons:
+					add(name, "ErrCheckViolation")
+				case eng == SQLite:
+				case c.kind == primaryKey && eng == MySQL:
+					add("PRIMARY", "ErrUniqueViolation", c.columns...)
+				case c.kind == foreignKey:
+					add(name, "ErrForeignKeyViolation", c.columns...)
+				default:
+					add(name, "ErrUniqueViolation", c.columns...)
+				}
+			}
+
+			switch eng {
+			case Postgres:
+				for _, col := range t.Columns {
+					if col.cons&primaryKey != 0 {
+						add(postgresName(t.Name, "", "pkey"), "ErrUniqueViolation", col.Name)
+					}
+					if col.cons&uniqueCons != 0 {
+						add(postgresName(t.Name, col.Name, "key"), "ErrUniqueViolation", col.Name)
+					}
+					if col.cons&foreignKey != 0 {
+						add(postgresName(t.Name, col.Name, "fkey"), "ErrForeignKeyViolation", col.Name)
+					}
+				}
+
+			case MySQL:
+				// The foreign keys defined at column level are ignored by MySQL.
+				for _, col := range t.Columns {
+					if col.cons&primaryKey != 0 {
+						add("PRIMARY", "ErrUniqueViolation", col.Name)
+					}
+					if col.cons&uniqueCons != 0 {
+						add(col.Name, "ErrUniqueViolation", col.Name)
+					}
+				}
+
+			default:
+				continue
+			}
+
+			for _, col := range t.Columns {
+				if col.index == uniqIndex {
+					add(engineName(fmt.Sprintf("idx_%s_%s", t.Name, col.Name), eng),
+						"ErrUniqueViolation", col.Name)
+				}
+			}
+			for _, v := range t.index {
+				if v.isUnique {
+					add(engineName(v.defaultName(), eng), "ErrUniqueViolation", v.index...)
+				}
+			}
+		}
+
+		if len(list) == 0 {
+			continue
+		}
+		code += fmt.Sprintf("modsql.RegisterConstraints(modsql.%s,\n", eng)
+		for _, c := range list {
+			columns := make([]string, len(c.columns))
+			for i, v := range c.columns {
+				columns[i] = strconv.Quote(v)
+			}
+			fields := fmt.Sprintf("Name: %q, Kind: modsql.%s, Table: %q", c.name, c.kind, c.table)
+			if len(columns) != 0 {
+				fields += fmt.Sprintf(", Columns: []string{%s}", strings.Join(columns, ", "))
+			}
+			code += "modsql.Constraint{" + fields + "},\n"
+		}
+		code += ")\n"
+	}
+
+	if code == "" {
+		return ""
+	}
+	return "\nfunc init() {\n" + code + "}\n"
+}
+
+// genStatementsByEngine generates the variable with the given name to handle
+// the statements which are different for every engine.
+func (md *metadata) genStatementsByEngine(name string, stmts map[Engine][]string) string {
+	if len(stmts) == 0 {
+		return ""
+	}
+
+	code := fmt.Sprintf("\nvar %s = modsql.NewStatementsByEngine(map[modsql.Engine]map[int]string{\n", name)
+	for _, eng := range md.engines {
+		code += fmt.Sprintf("modsql.%s: {\n%s,\n},\n", eng, strings.Join(stmts[eng], ",\n"))
+	}
+	return code + "})\n"
+}
+
+// hasEngine reports whether the model is generated for the engine.
+func (md *metadata) hasEngine(eng Engine) bool {
+	for _, v := range md.engines {
+		if v == eng {
+			return true
+		}
+	}
+	return false
+}
+
+// genUpsertForType generates the SQL statements and the Go code to insert a
+// row or to update it if its key already exists.
+// It returns an empty string if the table has not a key to use.
+func (md *metadata) genUpsertForType(idx int, t *table) string {
+	key := t.upsertKey
+	if len(key) == 0 {
+		if key = t.primaryKey(); len(key) == 0 {
+			return ""
+		}
+	} else if !t.isUniqueKey(key) {
+		log.Fatalf("table %q: Upsert(): columns %q have not a primary key or unique constraint",
+			t.Name, key)
+	}
+
+	insertCols := t.insertColumns()
+
+	update := t.upsertUpdate
+	if len(update) == 0 {
+		for _, col := range insertCols {
+			inKey := false
+			for _, k := range key {
+				if col.Name == k {
+					inKey = true
+					break
+				}
+			}
+			if !inKey {
+				update = append(update, col.Name)
+			}
+		}
+	}
+
+	columns := make([]string, len(insertCols))
+	for i, col := range insertCols {
+		columns[i] = quoteStatementSQL(col.Name)
+	}
+	values := strings.Repeat("{P}, ", len(columns))
+
+	// The time of update is set, and the row is not marked like deleted,
+	// when there is something to update.
+	var touch []string
+	if len(update) != 0 {
+		if t.timestamps {
+			touch = append(touch, updatedAt+" = {NOW}")
+		}
+		if t.softDelete {
+			touch = append(touch, deletedAt+" = NULL")
+		}
+	}
+	argsFunc := "Args"
+	if len(insertCols) != len(t.Columns) {
+		argsFunc = "insertArgs"
+	}
+
+	insert := fmt.Sprintf("INSERT INTO %s (%s) VALUES(%s)",
+		quoteStatementSQL(t.Name), strings.Join(columns, ", "), values[:len(values)-2])
+
+	for _, eng := range md.engines {
+		var set []string
+		var onConflict string
+
+		switch eng {
+		case MySQL:
+			for _, v := range update {
+				v = quoteStatementSQL(v)
+				set = append(set, fmt.Sprintf("%s = VALUES(%s)", v, v))
+			}
+			set = append(set, touch...)
+			if len(set) == 0 { // nothing to do, but the clause needs an assignment
+				k := quoteStatementSQL(key[0])
+				set = append(set, fmt.Sprintf("%s = %s", k, k))
+			}
+			onConflict = " ON DUPLICATE KEY UPDATE " + strings.Join(set, ", ")
+
+		case Postgres, SQLite:
+			for _, v := range update {
+				v = quoteStatementSQL(v)
+				set = append(set, fmt.Sprintf("%s = excluded.%s", v, v))
+			}
+			set = append(set, touch...)
+			keyQuoted := make([]string, len(key))
+			for i, v := range key {
+				keyQuoted[i] = quoteStatementSQL(v)
+			}
+			onConflict = fmt.Sprintf(" ON CONFLICT (%s) DO ", strings.Join(keyQuoted, ", "))
+			if len(set) == 0 {
+				onConflict += "NOTHING"
+			} else {
+				onConflict += "UPDATE SET " + strings.Join(set, ", ")
+			}
+		}
+
+		md.sqlUpsert[eng] = append(md.sqlUpsert[eng],
+			fmt.Sprintf("%d: \"%s%s\"", idx, insert, onConflict))
+	}
+
+	// MySQL updates the row on a conflict with whatever unique key.
+	mysqlNote := ""
+	if md.hasEngine(MySQL) {
+		var others []string
+		for _, v := range t.uniqueKeys() {
+			if !equalNames(v, key) {
+				others = append(others, strings.Join(v, ", "))
+			}
+		}
+		if len(others) != 0 {
+			mysqlNote = fmt.Sprintf("//\n// In MySQL, it is also updated on a conflict with the other unique keys (%s).\n",
+				strings.Join(others, "; "))
+		}
+	}
+
+	return fmt.Sprintf("\n\n// StmtUpsert returns the prepared statement to insert data, or to update\n"+
+		"// it if the key (%[1]s) already exists.\n%[5]s"+
+		"func (t *%[2]s) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(%[3]d) }\n\n"+
+
+		"// Upsert inserts the data, or updates it if the key (%[1]s) already exists.\n%[5]s"+
+		"func (t *%[2]s) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {\n"+
+		"return Upsert.ExecContext(ctx, q, %[3]d, t.%[4]s()...)\n"+
+		"}",
+		strings.Join(key, ", "), t.typeName(), idx, argsFunc, mysqlNote)
+}
+
+// genPKForType generates the SQL statements and the Go code to select, update
+// and delete a row of the table through its primary key.
+// It returns an empty string if the table has not primary key.
+func (md *metadata) genPKForType(idx int, t *table) string {
+	pk := t.primaryKey()
+	if len(pk) == 0 {
+		return ""
+	}
+	md.goImports["context"] = true
+
+	name := t.typeName()
+	tableName := quoteStatementSQL(t.Name)
+
+	var columns, set, setArgs []string
+	for _, col := range t.Columns {
+		columns = append(columns, quoteStatementSQL(col.Name))
+
+		if !t.isPrimaryKey(col.Name) && !col.auto {
+			set = append(set, quoteStatementSQL(col.Name)+" = {P}")
+			setArgs = append(setArgs, t.fieldArg(&col))
+		}
+	}
+	if t.timestamps && len(set) != 0 {
+		set = append(set, updatedAt+" = {NOW}")
+	}
+
+	where := make([]string, len(pk))
+	params := make([]string, len(pk))
+	paramNames := make([]string, len(pk))
+	pkArgs := make([]string, len(pk))
+	usedParams := make(map[string]bool)
+
+	for i, colName := range pk {
+		col := t.column(colName)
+
+		where[i] = quoteStatementSQL(colName) + " = {P}"
+		paramNames[i] = paramName(t.fieldName(colName), usedParams)
+		params[i] = paramNames[i] + " " + t.goType(col)
+		pkArgs[i] = "&t." + t.fieldName(colName)
+	}
+	whereSQL := and(strings.Join(where, " AND "), t.notDeleted(false))
+
+	md.sqlSelect = append(md.sqlSelect, fmt.Sprintf("%d: \"SELECT %s FROM %s WHERE %s\"",
+		idx, strings.Join(columns, ", "), tableName, whereSQL))
+	if t.softDelete {
+		md.sqlDelete = append(md.sqlDelete, fmt.Sprintf("%d: \"UPDATE %s SET %s = {NOW} WHERE %s\"",
+			idx, tableName, deletedAt, whereSQL))
+	} else {
+		md.sqlDelete = append(md.sqlDelete, fmt.Sprintf("%d: \"DELETE FROM %s WHERE %s\"",
+			idx, tableName, whereSQL))
+	}
+	md.sqlExists = append(md.sqlExists, fmt.Sprintf("%d: \"SELECT 1 FROM %s WHERE %s\"",
+		idx, tableName, whereSQL))
+
+	code := fmt.Sprintf(`
+
+// Get%[1]sByPK returns the row of %[2]s with the given primary key.
+func Get%[1]sByPK(ctx context.Context, q modsql.Querier, %[3]s) (*%[1]s, error) {
+	t := new(%[1]s)
+	err := SelectByPK.QueryRowContext(ctx, q, %[4]d, %[5]s).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *%[1]s) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, %[4]d, %[6]s)
+}
+
+func (t *%[1]s) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, %[4]d, %[6]s).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}`,
+		name, t.Name, strings.Join(params, ", "), idx,
+		strings.Join(paramNames, ", "), strings.Join(pkArgs, ", "))
+
+	// A table with only columns in the primary key has nothing to update.
+	if len(set) != 0 {
+		md.sqlUpdate = append(md.sqlUpdate, fmt.Sprintf("%d: \"UPDATE %s SET %s WHERE %s\"",
+			idx, tableName, strings.Join(set, ", "), whereSQL))
+
+		code += fmt.Sprintf(`
+
+func (t *%s) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, %d, %s)
+}`,
+			name, idx, strings.Join(append(setArgs, pkArgs...), ", "))
+	}
+
+	return code
+}
+
+// * * *
+
+// relation represents a foreign key from the columns src of the table child to
+// the columns dst of the table parent.
+type relation struct {
+	child, parent *table
+	src, dst      []string
+
+	oneToOne bool   // the foreign key is an unique key of the child
+	suffix   string // to differentiate the names of the methods
+}
+
+// relations returns the foreign keys between tables, also the ones which
+// reference tables of enumerations.
+func (md *metadata) relations() []*relation {
+	var rels []*relation
+
+	for _, t := range md.tables {
+		if t.isEnum {
+			continue
+		}
+
+		add := func(tableName string, src, dst []string) {
+			for _, parent := range md.tables {
+				if parent.Name == tableName {
+					rels = append(rels, &relation{
+						child: t, parent: parent, src: src, dst: dst,
+						oneToOne: t.isUniqueKey(src),
+					})
+					return
+				}
+			}
+		}
+
+		for _, col := range t.Columns {
+			if col.cons&foreignKey != 0 {
+				add(col.fkTable, []string{col.Name}, []string{col.fkColumn})
+			}
+		}
+		for _, fk := range t.fkCons {
+			add(fk.fkTable, fk.columns, fk.fkColumns)
+		}
+	}
+
+	// The names of the methods are got from the tables, so the columns of the
+	// foreign key are added when there are several relations between both
+	// tables or when a field or other method has the same name.
+	isUsed := func(t *table, name string) bool {
+		for _, col := range t.Columns {
+			if t.fieldName(col.Name) == name {
+				return true
+			}
+		}
+		for _, v := range typeMethods {
+			if v == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, r := range rels {
+		n := 0
+		for _, v := range rels {
+			if v.child == r.child && v.parent == r.parent {
+				n++
+			}
+		}
+		if n > 1 || isUsed(r.child, r.forwardName()) || isUsed(r.parent, r.reverseName()) {
+			r.suffix = "By" + fieldNames(r.child, r.src)
+		}
+	}
+	return rels
+}
+
+// forwardName returns the name of the method to get the parent.
+func (r *relation) forwardName() string { return r.parent.typeName() + r.suffix }
+
+// reverseName returns the name of the method to get the children.
+func (r *relation) reverseName() string {
+	if r.oneToOne {
+		return r.child.typeName() + r.suffix
+	}
+	return plural(r.child.typeName()) + r.suffix
+}
+
+// hasKeyColumn reports whether the foreign key has a single column whose
+// Go type can be used as key of a map.
+func (r *relation) hasKeyColumn() bool {
+	return len(r.src) == 1 && r.child.column(r.src[0]).type_ != Binary
+}
+
+// joinRelations returns the pairs of relations of the tables which only join
+// two other tables, to relate them as many-to-many.
+func (md *metadata) joinRelations(rels []*relation) [][2]*relation {
+	var pairs [][2]*relation
+
+	for _, t := range md.tables {
+		var pair []*relation
+		for _, r := range rels {
+			if r.child == t {
+				pair = append(pair, r)
+			}
+		}
+		if len(pair) != 2 || pair[0].parent == pair[1].parent ||
+			len(t.primaryKey()) != len(t.Columns) ||
+			len(pair[0].src)+len(pair[1].src) != len(t.Columns) {
+			continue
+		}
+		pairs = append(pairs, [2]*relation{pair[0], pair[1]})
+	}
+	return pairs
+}
+
+// genRelations generates the Go code to get the rows related through the
+// foreign keys, and to load them for several rows at once.
+// The variable Relations has all foreign keys, to check the orphan rows, but the
+// methods are not generated for the tables of enumerations, which have their
+// own Go type.
+func (md *metadata) genRelations() string {
+	all := md.relations()
+	if len(all) == 0 {
+		return ""
+	}
+	md.goImports["context"] = true
+
+	quote := func(a []string) string {
+		res := make([]string, len(a))
+		for i, v := range a {
+			res[i] = strconv.Quote(v)
+		}
+		return strings.Join(res, ", ")
+	}
+
+	code := "\n\n// Relations has the foreign keys between the tables.\nvar Relations = []modsql.Relation{\n"
+	var rels []*relation
+	for _, r := range all {
+		if !r.parent.isEnum {
+			rels = append(rels, r)
+		}
+		code += fmt.Sprintf("{Table: %q, Columns: []string{%s}, RefTable: %q, RefColumns: []string{%s}},\n",
+			r.child.Name, quote(r.src), r.parent.Name, quote(r.dst))
+	}
+	code += "}"
+
+	for _, r := range rels {
+		code += md.genRelation(r)
+	}
+	for _, pair := range md.joinRelations(rels) {
+		code += md.genManyToMany(pair[0], pair[1]) + md.genManyToMany(pair[1], pair[0])
+	}
+
+	// == Cascade delete
+	for _, t := range md.tables {
+		pk := t.primaryKey()
+		if t.isEnum || len(pk) == 0 {
+			continue
+		}
+		for _, r := range rels {
+			if r.parent == t && r.child != t {
+				code += genDeleteCascade(t, pk)
+				break
+			}
+		}
+	}
+	return code
+}
+
+// genDeleteCascade generates the Go code to delete a row and the rows which
+// depend on it.
+func genDeleteCascade(t *table, pk []string) string {
+	columns := make([]string, len(pk))
+	for i, v := range pk {
+		columns[i] = strconv.Quote(v)
+	}
+
+	return fmt.Sprintf(`
+
+// DeleteCascade deletes the row and the rows which depend on it through the
+// foreign keys, within a transaction. It returns the number of rows deleted by
+// table or, if dryRun is true, the number of rows which would be deleted.
+func (t *%s) DeleteCascade(ctx context.Context, q modsql.Querier, dryRun bool) (map[string]int64, error) {
+	return modsql.DeleteCascade(ctx, q, ENGINE, Relations, %q, []string{%s}, []interface{}{%s}, dryRun)
+}`,
+		t.typeName(), t.Name, strings.Join(columns, ", "), fieldArgs(t, pk))
+}
+
+// genRelation generates the Go code to get the parent of a row, and the
+// children of a row.
+func (md *metadata) genRelation(r *relation) string {
+	parentType := r.parent.typeName()
+	childType := r.child.typeName()
+
+	idx := md.addRelated(fmt.Sprintf("SELECT %s FROM %s WHERE %s",
+		selectColumns(r.parent, false), quoteStatementSQL(r.parent.Name),
+		and(whereColumns("", r.dst), r.parent.notDeleted(false))))
+
+	code := fmt.Sprintf(`
+
+// %[1]s returns the row of %[2]s referenced by the foreign key (%[3]s).
+func (t *%[4]s) %[1]s(ctx context.Context, q modsql.Querier) (*%[5]s, error) {
+	v := new(%[5]s)
+	if err := SelectRelated.QueryRowContext(ctx, q, %[6]d, %[7]s).Scan(v.Args()...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}`,
+		r.forwardName(), r.parent.Name, strings.Join(r.src, ", "), childType, parentType,
+		idx, fieldArgs(r.child, r.src))
+
+	idx = md.addRelated(fmt.Sprintf("SELECT %s FROM %s WHERE %s",
+		selectColumns(r.child, false), quoteStatementSQL(r.child.Name),
+		and(whereColumns("", r.src), r.child.notDeleted(false))))
+
+	if r.oneToOne {
+		code += fmt.Sprintf(`
+
+// %[1]s returns the row of %[2]s which refers to this row.
+func (t *%[3]s) %[1]s(ctx context.Context, q modsql.Querier) (*%[4]s, error) {
+	v := new(%[4]s)
+	if err := SelectRelated.QueryRowContext(ctx, q, %[5]d, %[6]s).Scan(v.Args()...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}`,
+			r.reverseName(), r.child.Name, parentType, childType, idx, fieldArgs(r.parent, r.dst))
+	} else {
+		code += fmt.Sprintf(`
+
+// %[1]s returns the rows of %[2]s which refer to this row.
+func (t *%[3]s) %[1]s(ctx context.Context, q modsql.Querier) ([]*%[4]s, error) {
+	rows, err := SelectRelated.QueryContext(ctx, q, %[5]d, %[6]s)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*%[4]s
+	for rows.Next() {
+		v := new(%[4]s)
+		if err = rows.Scan(v.Args()...); err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, rows.Err()
+}`,
+			r.reverseName(), r.child.Name, parentType, childType, idx, fieldArgs(r.parent, r.dst))
+	}
+
+	if !r.hasKeyColumn() {
+		return code
+	}
+
+	// == Batch loading
+	src, dst := r.child.fieldName(r.src[0]), r.parent.fieldName(r.dst[0])
+
+	code += fmt.Sprintf(`
+
+// Load%[1]sFor%[2]s%[3]s returns the rows of %[4]s referenced by the given rows,
+// by the value of %[5]s.
+func Load%[1]sFor%[2]s%[3]s(ctx context.Context, q modsql.Querier, rows []*%[6]s) (map[%[7]s]*%[8]s, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.%[9]s
+	}
+
+	res := make(map[%[7]s]*%[8]s)
+	err := modsql.QueryIn(ctx, q, ENGINE, %[10]q, keys, func(r *sql.Rows) error {
+		v := new(%[8]s)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.%[11]s] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}`,
+		plural(parentType), plural(childType), r.suffix, r.parent.Name, r.dst[0], childType,
+		r.parent.column(r.dst[0]).type_.goString(), parentType, src,
+		fmt.Sprintf("SELECT %s FROM %s WHERE %s", selectColumns(r.parent, false),
+			quoteStatementSQL(r.parent.Name), and(r.parent.notDeleted(false), quoteStatementSQL(r.dst[0]))),
+		dst)
+
+	resType, add := "[]*"+childType, fmt.Sprintf("append(res[v.%s], v)", src)
+	if r.oneToOne {
+		resType, add = "*"+childType, "v"
+	}
+
+	code += fmt.Sprintf(`
+
+// Load%[1]sFor%[2]s%[3]s returns the rows of %[4]s which refer to the given rows,
+// by the value of %[5]s.
+func Load%[1]sFor%[2]s%[3]s(ctx context.Context, q modsql.Querier, rows []*%[6]s) (map[%[7]s]%[8]s, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.%[9]s
+	}
+
+	res := make(map[%[7]s]%[8]s)
+	err := modsql.QueryIn(ctx, q, ENGINE, %[10]q, keys, func(r *sql.Rows) error {
+		v := new(%[11]s)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.%[12]s] = %[13]s
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}`,
+		plural(childType), plural(parentType), r.suffix, r.child.Name, r.src[0], parentType,
+		r.child.column(r.src[0]).type_.goString(), resType, dst,
+		fmt.Sprintf("SELECT %s FROM %s WHERE %s", selectColumns(r.child, false),
+			quoteStatementSQL(r.child.Name), and(r.child.notDeleted(false), quoteStatementSQL(r.src[0]))),
+		childType, src, add)
+
+	return code
+}
+
+// genManyToMany generates the Go code to get the rows of the parent of other,
+// related to a row of the parent of this one through their join table.
+func (md *metadata) genManyToMany(this, other *relation) string {
+	join := other.child
+	thisType := this.parent.typeName()
+	otherType := other.parent.typeName()
+	joinName := quoteStatementSQL(join.Name)
+	otherName := quoteStatementSQL(other.parent.Name)
+
+	on := make([]string, len(other.src))
+	for i := range other.src {
+		on[i] = fmt.Sprintf("%s.%s = %s.%s", joinName, quoteStatementSQL(other.src[i]),
+			otherName, quoteStatementSQL(other.dst[i]))
+	}
+	from := fmt.Sprintf("%s JOIN %s ON %s", otherName, joinName, strings.Join(on, " AND "))
+
+	notDeleted := and(other.parent.notDeleted(true), join.notDeleted(true))
+
+	idx := md.addRelated(fmt.Sprintf("SELECT %s FROM %s WHERE %s",
+		selectColumns(other.parent, true), from, and(whereColumns(join.Name, this.src), notDeleted)))
+
+	code := fmt.Sprintf(`
+
+// %[1]s returns the rows of %[2]s related to this row through %[3]s.
+func (t *%[4]s) %[1]s(ctx context.Context, q modsql.Querier) ([]*%[5]s, error) {
+	rows, err := SelectRelated.QueryContext(ctx, q, %[6]d, %[7]s)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*%[5]s
+	for rows.Next() {
+		v := new(%[5]s)
+		if err = rows.Scan(v.Args()...); err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, rows.Err()
+}`,
+		plural(otherType), other.parent.Name, join.Name, thisType, otherType,
+		idx, fieldArgs(this.parent, this.dst))
+
+	if !this.hasKeyColumn() {
+		return code
+	}
+
+	code += fmt.Sprintf(`
+
+// Load%[1]sFor%[2]s returns the rows of %[3]s related to the given rows through
+// %[4]s, by the value of %[5]s.
+func Load%[1]sFor%[2]s(ctx context.Context, q modsql.Querier, rows []*%[6]s) (map[%[7]s][]*%[8]s, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.%[9]s
+	}
+
+	res := make(map[%[7]s][]*%[8]s)
+	err := modsql.QueryIn(ctx, q, ENGINE, %[10]q, keys, func(r *sql.Rows) error {
+		var key %[7]s
+		v := new(%[8]s)
+		if err := r.Scan(append(v.Args(), &key)...); err != nil {
+			return err
+		}
+		res[key] = append(res[key], v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}`,
+		plural(otherType), plural(thisType), other.parent.Name, join.Name, this.dst[0],
+		thisType, join.column(this.src[0]).type_.goString(), otherType,
+		this.parent.fieldName(this.dst[0]),
+		fmt.Sprintf("SELECT %s, %s.%s FROM %s WHERE %s", selectColumns(other.parent, true),
+			joinName, quoteStatementSQL(this.src[0]), from,
+			and(notDeleted, joinName+"."+quoteStatementSQL(this.src[0]))),
+	)
+
+	return code
+}
+
+// addRelated adds a statement to get related rows, returning its index.
+func (md *metadata) addRelated(query string) int {
+	idx := len(md.sqlRelated)
+	md.sqlRelated = append(md.sqlRelated, fmt.Sprintf("%d: \"%s\"", idx, query))
+	return idx
+}
+
+// selectColumns returns the columns of the table to select, qualified by the
+// table name if qualify is true.
+func selectColumns(t *table, qualify bool) string {
+	columns := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		columns[i] = quoteStatementSQL(col.Name)
+		if qualify {
+			columns[i] = quoteStatementSQL(t.Name) + "." + columns[i]
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
+// whereColumns returns the condition to compare every column with a parameter,
+// qualified by the table name if it is set.
+func whereColumns(tableName string, columns []string) string {
+	where := make([]string, len(columns))
+	for i, v := range columns {
+		where[i] = quoteStatementSQL(v) + " = {P}"
+		if tableName != "" {
+			where[i] = quoteStatementSQL(tableName) + "." + where[i]
+		}
+	}
+	return strings.Join(where, " AND ")
+}
+
+// fieldArgs returns the fields of the columns in the receiver "t".
+func fieldArgs(t *table, columns []string) string {
+	args := make([]string, len(columns))
+	for i, v := range columns {
+		args[i] = "t." + t.fieldName(v)
+	}
+	return strings.Join(args, ", ")
+}
+
+// fieldNames returns the names of the fields of the columns, joined.
+func fieldNames(t *table, columns []string) string {
+	names := make([]string, len(columns))
+	for i, v := range columns {
+		names[i] = t.fieldName(v)
+	}
+	return strings.Join(names, "")
+}
+
+// plural returns the plural of an English noun, to name the methods which
+// return several rows.
+func plural(s string) string {
+	switch {
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"), strings.HasSuffix(s, "z"),
+		strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	case len(s) > 1 && s[len(s)-1] == 'y' && !strings.ContainsRune("aeiouAEIOU", rune(s[len(s)-2])):
+		return s[:len(s)-1] + "ies"
+	}
+	return s + "s"
+}
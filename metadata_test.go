@@ -0,0 +1,49 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUpsertQuoted(t *testing.T) {
+	resetColumnsErr(t)
+
+	md := Metadata("model", Postgres)
+	tb := Table("item", md,
+		Column("id", Int).PrimaryKey(),
+		Column("user", Int).Unique(),
+		Column("name", String),
+	)
+	tb.Upsert([]string{"user"})
+	md.genUpsertForType(0, tb)
+
+	want := `0: "INSERT INTO item (id, {Q}user{Q}, name) VALUES({P}, {P}, {P})` +
+		` ON CONFLICT ({Q}user{Q}) DO UPDATE SET id = excluded.id, name = excluded.name"`
+	if got := md.sqlUpsert[Postgres][0]; got != want {
+		t.Errorf("got %s\nwant %s", got, want)
+	}
+}
+
+func TestUpsertMySQL(t *testing.T) {
+	resetColumnsErr(t)
+
+	md := Metadata("model", MySQL)
+	tb := Table("item", md,
+		Column("id", Int).PrimaryKey(),
+		Column("code", String).Unique(),
+		Column("name", String),
+	)
+	tb.Unique("name", "code")
+	tb.Upsert([]string{"code"})
+
+	want := "// In MySQL, it is also updated on a conflict with the other unique keys (id; name, code)."
+	if got := md.genUpsertForType(0, tb); !strings.Contains(got, want) {
+		t.Errorf("expected the note about the other unique keys in\n%s", got)
+	}
+}
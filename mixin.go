@@ -0,0 +1,157 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// mixin represents a group of columns, with their indexes and constraints,
+// to be added to several tables.
+type mixin struct {
+	Name    string
+	columns []column
+
+	uniqueCons [][]string
+	index      []*compoIndex
+
+	embedded bool // to generate a Go type embedded in the types of the tables
+	goName   string
+}
+
+// Mixin defines a group of columns which can be added to tables through the
+// method Mixin of the table.
+func Mixin(name string, col ...*column) *mixin {
+	if len(col) == 0 {
+		log.Fatalf("mixin %q: no columns", name)
+	}
+	if len(columnsErr) != 0 {
+		log.Fatalf("wrong type for default value in mixin %q: %s",
+			name, strings.Join(columnsErr, ", "))
+	}
+
+	m := &mixin{Name: name}
+	for _, v := range col {
+		m.columns = append(m.columns, *v)
+	}
+	return m
+}
+
+// Index creates an index on a group of columns of the mixin.
+func (m *mixin) Index(unique bool, columns ...string) *mixin {
+	m.existColumns("Index", columns)
+	m.index = append(m.index, &compoIndex{isUnique: unique, index: columns})
+	return m
+}
+
+// Unique creates a composite unique constraint on columns of the mixin.
+// It can be called several times to add more constraints.
+func (m *mixin) Unique(columns ...string) *mixin {
+	m.existColumns("Unique", columns)
+	m.uniqueCons = append(m.uniqueCons, columns)
+	return m
+}
+
+// Embedded generates a Go type for the mixin, which is embedded in the Go types
+// of the tables, so its fields are defined once.
+func (m *mixin) Embedded() *mixin {
+	m.embedded = true
+	return m
+}
+
+// GoName sets the name of the Go type of the mixin, when it is embedded.
+func (m *mixin) GoName(name string) *mixin {
+	m.goName = name
+	return m
+}
+
+// typeName returns the name of the Go type of the mixin.
+func (m *mixin) typeName(md *metadata) string {
+	if m.goName != "" {
+		return m.goName
+	}
+	return md.naming(m.Name)
+}
+
+// existColumns checks if the mixin has the columns.
+func (m *mixin) existColumns(funcName string, columns []string) {
+	for _, name := range columns {
+		found := false
+		for _, c := range m.columns {
+			if c.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			log.Fatalf("mixin %q: %s(): column %q does not exist", m.Name, funcName, name)
+		}
+	}
+}
+
+// Mixin adds the columns, indexes and constraints of the mixins to the table.
+// It has to be called before of inserting values.
+func (t *table) Mixin(mixins ...*mixin) {
+	if t.isEnum {
+		log.Fatalf("table %q: Mixin(): it is a table of enumeration", t.Name)
+	}
+	if len(t.data) != 0 || len(t.testData) != 0 {
+		log.Fatalf("table %q: Mixin(): it has to be called before of inserting values", t.Name)
+	}
+
+	for _, m := range mixins {
+		for _, c := range m.columns {
+			if t.column(c.Name) != nil {
+				log.Fatalf("table %q: Mixin(%q): column %q already exists", t.Name, m.Name, c.Name)
+			}
+			if m.embedded {
+				c.mixin = m
+			}
+			t.Columns = append(t.Columns, c)
+		}
+
+		for _, v := range m.uniqueCons {
+			t.Unique(v...)
+		}
+		for _, v := range m.index {
+			idx := *v
+			idx.table = t
+			t.index = append(t.index, &idx)
+		}
+
+		if m.embedded && !t.meta.hasMixin(m) {
+			t.meta.mixins = append(t.meta.mixins, m)
+		}
+	}
+}
+
+// hasMixin reports whether the mixin is already used in some table.
+func (md *metadata) hasMixin(m *mixin) bool {
+	for _, v := range md.mixins {
+		if v == m {
+			return true
+		}
+	}
+	return false
+}
+
+// genMixin generates the Go type for an embedded mixin, from the columns of
+// the table where it is used.
+func (md *metadata) genMixin(t *table, m *mixin) string {
+	code := fmt.Sprintf("\n// %s has the columns of the mixin %q.\ntype %[1]s struct {\n",
+		m.typeName(md), m.Name)
+
+	for _, col := range t.Columns {
+		if col.mixin == m {
+			code += fmt.Sprintf("%s%s %s %s\n", goComment(col.comment),
+				t.fieldName(col.Name), t.goType(&col), t.fieldTags(&col))
+		}
+	}
+	return code + "}\n"
+}
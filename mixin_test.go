@@ -0,0 +1,39 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMixin(t *testing.T) {
+	resetColumnsErr(t)
+
+	md := Metadata("model", SQLite)
+	audit := Mixin("audit",
+		Column("created_by", String),
+		Column("updated_by", String),
+	).Index(false, "created_by").Embedded()
+
+	for _, name := range []string{"book", "author"} {
+		tb := Table(name, md, Column("id", Int).PrimaryKey())
+		tb.Mixin(audit)
+
+		if len(tb.Columns) != 3 || tb.Columns[1].mixin != audit || len(tb.index) != 1 {
+			t.Errorf("table %q: got columns %v and indexes %v", name, tb.Columns, tb.index)
+		}
+	}
+	if len(md.mixins) != 1 {
+		t.Errorf("expected to get the mixin once, got %d", len(md.mixins))
+	}
+
+	code := md.genMixin(md.tables[0], audit)
+	if !strings.Contains(code, "type Audit struct {\nCreated_by string") {
+		t.Errorf("got Go type:\n%s", code)
+	}
+}
@@ -16,13 +16,31 @@ import (
 )
 
 func taskModelSQL(*tasking.T) {
-	metadata := Metadata("model", Postgres, MySQL, SQLite)
+	metadata := Metadata("model", Postgres, MySQL, SQLite).NameStrategy(CamelName)
 
 	Enum("sex", metadata, Int8, 0,
 		"female",
 		"male",
 	)
 
+	Enum("status", metadata, Int8, 1).
+		Attrs(
+			Column("label", String),
+			Column("sort_order", Int),
+			Column("active", Bool),
+		).
+		Value("draft", "Draft", 2, true).
+		Value("published", "Published", 1, true).
+		Value("archived", "Archived", 3, false)
+
+	mood := NativeEnum("mood", metadata, "sad", "ok", "happy")
+
+	person := Table("person", metadata,
+		Column("person_id", Int).PrimaryKey(),
+		Column("sex", Int8).ForeignKey("sex", "id"),
+		mood.Column("mood"),
+	)
+
 	types := Table("types", metadata,
 		Column("int_", Int).PrimaryKey(),
 		Column("int8_", Int8),
@@ -41,15 +59,17 @@ func taskModelSQL(*tasking.T) {
 		Column("bool_", Bool),
 	)
 	types.Unique("float32_", "float64_")
+	types.Unique("byte_", "rune_")
 	types.Index(true, "int16_", "int32_")
+	types.Upsert([]string{"string_"}, "int8_", "int16_")
 
 	def := Table("default_value", metadata,
 		Column("id", Int).PrimaryKey(),
 		Column("int8_", Int8).Default(int8(55)),
 		Column("float32_", Float32).Default(float32(10.2)),
 
-		Column("string_", String),
-		Column("binary_", Binary),
+		Column("string_", String).Default("it's"),
+		Column("binary_", Binary).Default([]byte("ab")),
 
 		Column("byte_", Byte).Default(byte('b')),
 		Column("rune_", Rune).Default('r'),
@@ -60,13 +80,15 @@ func taskModelSQL(*tasking.T) {
 	times := Table("times", metadata,
 		Column("typeId", Int),
 		//Column("duration", Duration),
-		Column("datetime", DateTime),
+		Column("datetime", DateTime).GoName("DateTime").DefaultExpr("{{.Now}}"),
 	)
 
 	// Insert values
 
 	types.Insert(0, 8, 16, 32, 64, 1.32, 1.64, "one", []byte("12"), 'A', 'Z', true)
 
+	person.Insert(0, 1, "happy")
+
 	def.InsertTestData(0, 10, 10.10, "foo", []byte{'1', '2'}, 'a', 'z', false)
 
 	times.Insert(0,
@@ -74,6 +96,26 @@ func taskModelSQL(*tasking.T) {
 		time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC))
 	times.Insert(1, time.Time{})
 
+	// == Mixins
+
+	tenant := Mixin("tenant", Column("tenant_id", Int)).Index(false, "tenant_id")
+	audit := Mixin("audit",
+		Column("created_by", String),
+		Column("updated_by", String),
+	).Embedded()
+
+	note := Table("note", metadata,
+		Column("note_id", Int).PrimaryKey(),
+		Column("body", String).Comment("Text written by the user, in Markdown; it's not sanitized."),
+	)
+	note.Comment("Notes attached to the tenant.")
+	note.Mixin(tenant, audit)
+	note.Timestamps()
+	note.SoftDelete()
+	note.Index(false, "tenant_id", "created_at").Name("idx_note_recent").Desc("created_at")
+
+	note.Insert(0, "a", 1, "foo", "bar")
+
 	// == Examples of relationships
 	//
 
@@ -85,6 +127,8 @@ func taskModelSQL(*tasking.T) {
 		Column("acc_descr", String),
 	)
 	accounts.PrimaryKey("acc_num", "acc_type")
+	accounts.Unique("acc_type", "acc_descr").Name("uq_account_descr")
+	accounts.Check("acc_num > 0")
 
 	subAccounts := Table("sub_account", metadata,
 		Column("sub_acc", Int).PrimaryKey(),
@@ -101,12 +145,13 @@ func taskModelSQL(*tasking.T) {
 	// == One-to-one
 	// For related entities which share basic attributes.
 
-	Table("catalog", metadata,
+	catalog := Table("catalog", metadata,
 		Column("catalog_id", Int).PrimaryKey(),
-		Column("name", String),
-		Column("description", String),
+		Column("name", String).Size(60),
+		Column("description", String).OmitEmpty().Index(false).IndexPrefix(100),
 		Column("price", Float32),
 	)
+	catalog.Index(false).Name("idx_catalog_lower_name").Expr("lower(name)")
 
 	Table("magazine", metadata,
 		Column("catalog_id", Int).PrimaryKey().ForeignKey("catalog", "catalog_id"),
@@ -151,7 +196,7 @@ func taskModelSQL(*tasking.T) {
 		Column("address_id", Int).PrimaryKey(),
 		Column("street", String),
 		Column("city", String),
-		Column("state", String),
+		Column("state", Char).Size(2),
 		Column("post_code", String),
 	)
 
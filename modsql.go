@@ -8,11 +8,17 @@ package modsql
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"reflect"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
@@ -20,7 +26,7 @@ import (
 
 // namesToQuote are names which have to be quoted to be used in SQL statements
 // (tables and columns).
-var namesToQuote = [...]string{"user"}
+var namesToQuote = [...]string{"user", "order", "group"}
 
 // sqlInt has the integer type for the SQL engine according to the architecture.
 // The values could be changed in function Load according to the architecture.
@@ -38,47 +44,346 @@ func init() {
 	log.SetPrefix("FAIL: ")
 }
 
-// Modeler is the interface that wraps the basic Args and StmtInsert methods
-// generated in the file "sqlmodel.go".
+// Modeler is the interface that wraps the basic methods generated in the file
+// "sqlmodel.go".
 //
 // Args returns the data. It is to be used in prepared statements.
 //
 // StmtInsert returns the prepared statement to insert data into a later execution.
+//
+// Insert inserts the data through a database, transaction or connection.
+//
+// Columns returns the name of the columns, in the same order than Args.
+//
+// Scan copies the columns in the current row into the fields with the same
+// name, so the query has not to select the columns in the order of Args.
 type Modeler interface {
 	Args() []interface{}
-	StmtInsert() *sql.Stmt
+	StmtInsert() (*sql.Stmt, error)
+	Insert(ctx context.Context, q Querier) (sql.Result, error)
+
+	Columns() []string
+	Scan(rows *sql.Rows, opts ...ScanOption) error
+}
+
+// Querier is the interface that wraps the methods to run statements.
+// It is satisfied by *sql.DB, *sql.Tx and *sql.Conn.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
 
-// SQLReplacer replaces "{P}" with the placeholder parameter and "{Q} with
-// the quote character, according to the SQL engine.
+// A ScanOption changes how the columns are scanned, in the call where it is
+// passed.
+type ScanOption int
+
+const (
+	// IgnoreUnknownColumns makes to discard the columns which are not in the
+	// Go type at scanning them, instead of returning an error.
+	IgnoreUnknownColumns ScanOption = iota + 1
+)
+
+// An UnknownColumnError is returned at scanning a column which is not in the
+// Go type.
+type UnknownColumnError struct {
+	Table  string
+	Column string
+}
+
+func (e *UnknownColumnError) Error() string {
+	return fmt.Sprintf("table %q: column %q not found in Go type", e.Table, e.Column)
+}
+
+// UnknownColumn returns the destination to scan a column which is not in the
+// Go type of the table, according to the option IgnoreUnknownColumns.
+// It is to be called from the Go code generated.
+func UnknownColumn(table, column string, opts ...ScanOption) (interface{}, error) {
+	for _, v := range opts {
+		if v == IgnoreUnknownColumns {
+			return new(interface{}), nil
+		}
+	}
+	return nil, &UnknownColumnError{table, column}
+}
+
+// A CharField is the argument of a field whose column is of type CHAR, to
+// remove the trailing blanks added by engines like Postgres at scanning it.
+// It is to be called from the Go code generated.
+type CharField struct {
+	S *string
+}
+
+// Scan implements the sql.Scanner interface.
+func (f CharField) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		*f.S = strings.TrimRight(v, " ")
+	case []byte:
+		*f.S = strings.TrimRight(string(v), " ")
+	case nil:
+		*f.S = ""
+	default:
+		return fmt.Errorf("unsupported type %T for a column of type CHAR", src)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (f CharField) Value() (driver.Value, error) { return *f.S, nil }
+
+// SQLReplacer replaces "{P}" with the placeholder parameter, "{Q} with the
+// quote character, and "{NOW}" with the current time in UTC, according to the
+// SQL engine.
+// The tokens inside strings, quoted identifiers and comments are not replaced.
 func SQLReplacer(eng Engine, src string) string {
+	dst, _ := SQLNamedReplacer(eng, src)
+	return dst
+}
+
+// SQLNamedReplacer is like SQLReplacer but it also replaces the named place
+// holders "{P:name}".
+// In Postgres, the same name is replaced by the same parameter "$n"; in MySQL
+// and SQLite, every place holder is replaced by "?".
+//
+// Returns the names of the arguments in the order in which they have to be
+// passed; the positional place holders have an empty name.
+func SQLNamedReplacer(eng Engine, src string) (string, []string) {
 	switch eng {
-	case MySQL, SQLite:
-		src = strings.Replace(src, "{P}", "?", -1)
+	case MySQL, Postgres, SQLite:
+	default:
+		panic("engine not supported: " + eng.String())
+	}
+
+	var names []string
+	var index map[string]int // Postgres: name to number of parameter
+
+	dst := make([]byte, 0, len(src)+len(src)/8)
+	var quote byte // quote of the string or identifier being copied
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+
+		if quote != 0 {
+			// The escaped quote ('') is handled as two strings.
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && eng == MySQL && i+1 < len(src) {
+				dst = append(dst, c, src[i+1]) // backslash escape
+				i++
+				continue
+			}
+			dst = append(dst, c)
+			continue
+		}
+		if c == '\'' || c == '"' || c == '`' {
+			quote = c
+			dst = append(dst, c)
+			continue
+		}
+		// The comments are copied like they are.
+		if end := commentEnd(eng, src[i:]); end != 0 {
+			dst = append(dst, src[i:i+end]...)
+			i += end - 1
+			continue
+		}
+		if c != '{' {
+			dst = append(dst, c)
+			continue
+		}
 
-		if strings.Contains(src, "{Q}") {
-			return strings.Replace(src, "{Q}", quoteChar[eng], -1)
+		switch {
+		case strings.HasPrefix(src[i:], "{Q}"):
+			dst = append(dst, quoteChar[eng]...)
+			i += len("{Q}") - 1
+
+		case strings.HasPrefix(src[i:], "{NOW}"):
+			dst = append(dst, nowSQL[eng]...)
+			i += len("{NOW}") - 1
+
+		case strings.HasPrefix(src[i:], "{P}"):
+			names = append(names, "")
+			if eng == Postgres {
+				dst = append(dst, '$')
+				dst = strconv.AppendInt(dst, int64(len(names)), 10)
+			} else {
+				dst = append(dst, '?')
+			}
+			i += len("{P}") - 1
+
+		case strings.HasPrefix(src[i:], "{P:"):
+			end := strings.IndexByte(src[i:], '}')
+			if end == -1 || end == len("{P:") {
+				dst = append(dst, c)
+				continue
+			}
+			name := src[i+len("{P:") : i+end]
+
+			if eng == Postgres {
+				if index == nil {
+					index = make(map[string]int)
+				}
+				n, ok := index[name]
+				if !ok {
+					names = append(names, name)
+					n = len(names)
+					index[name] = n
+				}
+				dst = append(dst, '$')
+				dst = strconv.AppendInt(dst, int64(n), 10)
+			} else {
+				names = append(names, name)
+				dst = append(dst, '?')
+			}
+			i += end
+
+		default:
+			dst = append(dst, c)
 		}
+	}
+	return string(dst), names
+}
 
-	case Postgres:
-		for nParam := 1; strings.Contains(src, "{P}"); nParam++ {
-			src = strings.Replace(src, "{P}", fmt.Sprintf("$%d", nParam), 1)
+// commentEnd returns the length of the comment at the start of s, or 0 if
+// there is not a comment. A comment "--" or "#", in MySQL, ends before of the
+// new line; in MySQL, "--" has to be followed by a blank.
+func commentEnd(eng Engine, s string) int {
+	switch {
+	case strings.HasPrefix(s, "/*"):
+		if end := strings.Index(s[2:], "*/"); end != -1 {
+			return end + 4
 		}
+		return len(s)
 
-		if strings.Contains(src, "{Q}") {
-			return strings.Replace(src, "{Q}", quoteChar[eng], -1)
+	case strings.HasPrefix(s, "--"):
+		if eng == MySQL && len(s) > 2 && s[2] != ' ' && s[2] != '\t' && s[2] != '\n' {
+			return 0
 		}
+	case strings.HasPrefix(s, "#") && eng == MySQL:
 	default:
-		panic("engine not supported: " + eng.String())
+		return 0
+	}
+
+	if end := strings.IndexByte(s, '\n'); end != -1 {
+		return end
 	}
-	return src
+	return len(s)
+}
+
+// NamedArgs returns the values for the names, in the same order, to be used
+// like arguments of a statement created by SQLNamedReplacer.
+//
+// The values are got from arg, which has to be a map[string]interface{} or a
+// struct, or a pointer to it. The fields of a struct are matched by its tag
+// "db" or else by its name, ignoring the case, also into the embedded structs;
+// the fields with the tag "db:\"-\"" are skipped.
+func NamedArgs(names []string, arg interface{}) ([]interface{}, error) {
+	args := make([]interface{}, len(names))
+
+	if m, ok := arg.(map[string]interface{}); ok {
+		for i, name := range names {
+			if name == "" {
+				return nil, fmt.Errorf("argument %d: positional place holder", i)
+			}
+			v, ok := m[name]
+			if !ok {
+				return nil, fmt.Errorf("argument %q not found", name)
+			}
+			args[i] = v
+		}
+		return args, nil
+	}
+
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("wrong type for named arguments: %T", arg)
+	}
+	typ := v.Type()
+
+	for i, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("argument %d: positional place holder", i)
+		}
+
+		field, found := structField(v, name)
+		if !found {
+			return nil, fmt.Errorf("argument %q not found in type %s", name, typ)
+		}
+		args[i] = field.Interface()
+	}
+	return args, nil
+}
+
+// structField returns the field of the struct matched by name. The tags "db"
+// are matched before the names of the fields without tag, so a field tagged
+// with the name is not shadowed by a previous field with the same name.
+// The fields of the embedded structs, like the mixins, are searched after the
+// fields of the struct, like in "encoding/json".
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	typ := v.Type()
+	byName := -1
+	var embedded []reflect.Value
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("db")
+		if idx := strings.IndexByte(tag, ','); idx != -1 {
+			tag = tag[:idx]
+		}
+
+		// The exported fields of an unexported embedded struct can be read.
+		if field.Anonymous && tag == "" {
+			fv := v.Field(i)
+			if fv.Kind() == reflect.Ptr && !fv.IsNil() && field.PkgPath == "" {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				embedded = append(embedded, fv)
+				continue
+			}
+		}
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+
+		switch {
+		case tag == "-":
+		case tag == name:
+			return v.Field(i), true
+		case tag == "" && byName == -1 && strings.EqualFold(field.Name, name):
+			byName = i
+		}
+	}
+
+	if byName != -1 {
+		return v.Field(byName), true
+	}
+	for _, fv := range embedded {
+		if field, ok := structField(fv, name); ok {
+			return field, true
+		}
+	}
+	return reflect.Value{}, false
 }
 
 // Statements represents multiple SQL statements prepared to be used with
 // different place holders.
+//
+// The raw statements are not modified at preparing them, so the same set can
+// be prepared for several databases or engines. It is safe for concurrent use.
 type Statements struct {
-	raw  map[int]string
-	Stmt map[int]*sql.Stmt // to generate from raw
+	raw       map[int]string
+	rawEngine map[Engine]map[int]string // to use instead of raw
+
+	mu    sync.RWMutex
+	db    *sql.DB
+	eng   Engine
+	query map[int]string    // raw statements with the place holders of the engine
+	stmt  map[int]*sql.Stmt // to generate from query
 }
 
 // NewStatements returns a set of multiple statements.
@@ -86,51 +391,335 @@ type Statements struct {
 // and the quote character has to be "{Q}".
 func NewStatements(raw map[int]string) *Statements {
 	return &Statements{
-		raw,
-		make(map[int]*sql.Stmt, len(raw)),
+		raw:  raw,
+		stmt: make(map[int]*sql.Stmt, len(raw)),
+	}
+}
+
+// NewStatementsByEngine returns a set of multiple statements whose SQL is
+// different for every engine.
+func NewStatementsByEngine(raw map[Engine]map[int]string) *Statements {
+	return &Statements{
+		rawEngine: raw,
+		stmt:      make(map[int]*sql.Stmt),
+	}
+}
+
+// ErrNoDatabase is returned when a statement is requested before of setting
+// the database through Prepare or Bind.
+var ErrNoDatabase = errors.New("statements without database; use Prepare or Bind")
+
+// A PrepareError records the statements which could not be prepared.
+type PrepareError struct {
+	Engine Engine
+	Err    map[int]error // by key of statement
+}
+
+func (e *PrepareError) Error() string {
+	keys := make([]int, 0, len(e.Err))
+	for k := range e.Err {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	msg := make([]string, len(keys))
+	for i, k := range keys {
+		msg[i] = fmt.Sprintf("\n statement %d: %s", k, e.Err[k])
 	}
+	return fmt.Sprintf("prepare statements for %s:%s", e.Engine, strings.Join(msg, ""))
+}
+
+// Bind sets the database and the engine to use, so the statements are
+// prepared on its first use. The statements prepared for another database or
+// engine are closed.
+func (m *Statements) Bind(db *sql.DB, eng Engine) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.bind(db, eng)
+}
+
+// bind sets the database and the engine. It has to be called with the lock held.
+func (m *Statements) bind(db *sql.DB, eng Engine) error {
+	if err := eng.check(); err != nil {
+		return err
+	}
+	if m.db == db && m.eng == eng {
+		return nil
+	}
+	err := m.close()
+
+	raw := m.raw
+	if m.rawEngine != nil {
+		if raw = m.rawEngine[eng]; raw == nil {
+			return fmt.Errorf("statements not defined for %s", eng)
+		}
+	}
+
+	m.db, m.eng = db, eng
+	m.query = make(map[int]string, len(raw))
+	for k, v := range raw {
+		m.query[k] = SQLReplacer(eng, v)
+	}
+	return err
 }
 
 // Prepare creates the prepared statements.
-func (m *Statements) Prepare(db *sql.DB, eng Engine) {
-	for k, v := range m.raw {
-		m.raw[k] = SQLReplacer(eng, v)
+func (m *Statements) Prepare(db *sql.DB, eng Engine) error {
+	return m.PrepareContext(context.Background(), db, eng)
+}
+
+// PrepareContext creates the prepared statements using the context.
+// It prepares all statements, returning a *PrepareError with those which
+// failed; these ones will be tried again on its first use.
+func (m *Statements) PrepareContext(ctx context.Context, db *sql.DB, eng Engine) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.bind(db, eng); err != nil {
+		return err
 	}
 
-	for k, v := range m.raw {
-		stmt, err := db.Prepare(v)
+	errPrep := &PrepareError{eng, make(map[int]error)}
+
+	for k, v := range m.query {
+		if _, ok := m.stmt[k]; ok {
+			continue
+		}
+		stmt, err := m.db.PrepareContext(ctx, v)
 		if err != nil {
-			log.Fatal(err)
+			errPrep.Err[k] = err
+			continue
 		}
-		m.Stmt[k] = stmt
+		m.stmt[k] = stmt
+	}
+
+	if len(errPrep.Err) != 0 {
+		return errPrep
+	}
+	return nil
+}
+
+// Get returns the prepared statement for the key k, preparing it if it was
+// not already.
+func (m *Statements) Get(k int) (*sql.Stmt, error) {
+	return m.GetContext(context.Background(), k)
+}
+
+// GetContext is like Get but it uses the context to prepare the statement.
+func (m *Statements) GetContext(ctx context.Context, k int) (*sql.Stmt, error) {
+	m.mu.RLock()
+	stmt, ok := m.stmt[k]
+	m.mu.RUnlock()
+	if ok {
+		return stmt, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if stmt, ok = m.stmt[k]; ok { // prepared meanwhile
+		return stmt, nil
+	}
+	if m.db == nil {
+		return nil, ErrNoDatabase
+	}
+	query, ok := m.query[k]
+	if !ok {
+		return nil, fmt.Errorf("statement %d does not exist", k)
+	}
+
+	stmt, err := m.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, &PrepareError{m.eng, map[int]error{k: err}}
+	}
+	m.stmt[k] = stmt
+	return stmt, nil
+}
+
+// ExecContext executes the statement k through q, with the given arguments.
+// The constraint violations are returned like *ConstraintError.
+//
+// The prepared statement is used when q is the database which it was prepared
+// for, and it is rebound to q when it is a transaction, being closed after of
+// using it; else, the statement is run directly through q.
+func (m *Statements) ExecContext(ctx context.Context, q Querier, k int, args ...interface{}) (sql.Result, error) {
+	stmt, query, err := m.stmtFor(ctx, q, k, true)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := q.(*sql.Tx); ok && stmt != nil {
+		defer stmt.Close()
+	}
+	var res sql.Result
+	if stmt != nil {
+		res, err = stmt.ExecContext(ctx, args...)
+	} else {
+		res, err = q.ExecContext(ctx, query, args...)
+	}
+	if err != nil {
+		return nil, ClassifyError(m.engine(), err)
+	}
+	return res, nil
+}
+
+// QueryContext executes the statement k through q, with the given arguments,
+// returning the rows. The statement is got like in ExecContext, but it is run
+// directly through a transaction since it could not be closed until the rows
+// are closed.
+func (m *Statements) QueryContext(ctx context.Context, q Querier, k int, args ...interface{}) (*sql.Rows, error) {
+	stmt, query, err := m.stmtFor(ctx, q, k, false)
+	if err != nil {
+		return nil, err
+	}
+	if stmt != nil {
+		return stmt.QueryContext(ctx, args...)
 	}
+	return q.QueryContext(ctx, query, args...)
 }
 
-// Close closes all prepared statements.
+// QueryRowContext executes the statement k through q, with the given
+// arguments, returning at most a row. The statement is got like in ExecContext.
+//
+// If the statement could not be got, the query is not run and the error is
+// returned by the Scan method of *Row.
+func (m *Statements) QueryRowContext(ctx context.Context, q Querier, k int, args ...interface{}) *Row {
+	stmt, query, err := m.stmtFor(ctx, q, k, true)
+	if err != nil {
+		return &Row{err: err}
+	}
+	if stmt != nil {
+		row := &Row{row: stmt.QueryRowContext(ctx, args...)}
+		if _, ok := q.(*sql.Tx); ok {
+			row.stmt = stmt
+		}
+		return row
+	}
+	return &Row{row: q.QueryRowContext(ctx, query, args...)}
+}
+
+// Row is the result of Statements.QueryRowContext. It is like *sql.Row, but it
+// also holds the error got at getting the statement.
+type Row struct {
+	row  *sql.Row
+	stmt *sql.Stmt // bound to a transaction, to close after of scanning
+	err  error
+}
+
+// Scan copies the columns of the row into the values pointed at by dest.
+// It returns the error got at getting the statement, if any, or else the
+// error of *sql.Row.
+func (r *Row) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.stmt != nil {
+		defer r.stmt.Close()
+	}
+	return r.row.Scan(dest...)
+}
+
+// Err returns the error, if any, got at getting the statement or at running the
+// query.
+func (r *Row) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.row.Err()
+}
+
+// stmtFor returns the prepared statement k to be run through q, or else the
+// query to run directly. If bindTx is set and q is a transaction, it returns a
+// new statement bound to q, which has to be closed by the caller; else, the
+// query is returned for the transactions.
+func (m *Statements) stmtFor(ctx context.Context, q Querier, k int, bindTx bool) (*sql.Stmt, string, error) {
+	m.mu.RLock()
+	db := m.db
+	query, ok := m.query[k]
+	m.mu.RUnlock()
+
+	if db == nil {
+		return nil, "", ErrNoDatabase
+	}
+	if !ok {
+		return nil, "", fmt.Errorf("statement %d does not exist", k)
+	}
+
+	switch t := q.(type) {
+	case *sql.DB:
+		if t == db {
+			stmt, err := m.GetContext(ctx, k)
+			return stmt, "", err
+		}
+	case *sql.Tx:
+		if !bindTx {
+			break
+		}
+		stmt, err := m.GetContext(ctx, k)
+		if err != nil {
+			return nil, "", err
+		}
+		return t.StmtContext(ctx, stmt), "", nil
+	}
+	return nil, query, nil
+}
+
+// engine returns the engine set to prepare the statements.
+func (m *Statements) engine() Engine {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.eng
+}
+
+// Close closes all prepared statements, which have to be prepared again to be
+// used. The statements in use at calling it are closed once they finish.
+// It is safe to call it several times.
 // Returns the first error, if any.
 func (m *Statements) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	err := m.close()
+	m.db = nil
+	return err
+}
+
+// close closes all prepared statements. It has to be called with the lock held.
+func (m *Statements) close() error {
 	var err, errExit error
 
-	for _, v := range m.Stmt {
+	for k, v := range m.stmt {
 		if err = v.Close(); err != nil && errExit == nil {
 			errExit = err
 		}
+		delete(m.stmt, k)
 	}
 	return errExit
 }
 
 // listStatements represents a list of Statements to be prepared and closed
 // all together.
-var listStatements []*Statements
+var (
+	listStatements   []*Statements
+	listStatementsMu sync.Mutex
+)
 
 // InitStatements prepares all statements in "listStatements".
 // It hast to be called before of insert data.
-func InitStatements(db *sql.DB, eng Engine, stmts ...*Statements) {
+// Returns the first error, if any.
+func InitStatements(db *sql.DB, eng Engine, stmts ...*Statements) error {
+	var err, errExit error
+
+	listStatementsMu.Lock()
+	defer listStatementsMu.Unlock()
+
 	listStatements = make([]*Statements, len(stmts))
 	for i, v := range stmts {
-		v.Prepare(db, eng)
+		if err = v.Prepare(db, eng); err != nil && errExit == nil {
+			errExit = err
+		}
 		listStatements[i] = v
 	}
+	return errExit
 }
 
 // CloseStatements closes all statements in "listStatements".
@@ -138,6 +727,9 @@ func InitStatements(db *sql.DB, eng Engine, stmts ...*Statements) {
 func CloseStatements() error {
 	var err, errExit error
 
+	listStatementsMu.Lock()
+	defer listStatementsMu.Unlock()
+
 	for _, v := range listStatements {
 		if err = v.Close(); err != nil && errExit == nil {
 			errExit = err
@@ -225,6 +817,16 @@ func quoteSQL(name string) string {
 	return name
 }
 
+// quoteNamesSQL returns the names quoted for SQL and separated by commas, to use
+// into a template.
+func quoteNamesSQL(names []string) string {
+	quoted := make([]string, len(names))
+	for i, v := range names {
+		quoted[i] = quoteSQL(v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // quoteFieldSQL returns field name quoted for SQL, to use into a template.
 func quoteFieldSQL(name string) string {
 	for _, v := range namesToQuote {
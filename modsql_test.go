@@ -7,7 +7,13 @@
 package modsql
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -44,3 +50,422 @@ func TestSQLReplacer(t *testing.T) {
 		}
 	}
 }
+
+func TestSQLNamedReplacer(t *testing.T) {
+	src := "UPDATE foo SET a = {P:a}, b = {P}, c = 'x{P}{P:a}' WHERE a = {P:a} OR d = {P:d}"
+
+	tests := []struct {
+		eng   Engine
+		dst   string
+		names []string
+	}{
+		{Postgres,
+			"UPDATE foo SET a = $1, b = $2, c = 'x{P}{P:a}' WHERE a = $1 OR d = $3",
+			[]string{"a", "", "d"}},
+		{MySQL,
+			"UPDATE foo SET a = ?, b = ?, c = 'x{P}{P:a}' WHERE a = ? OR d = ?",
+			[]string{"a", "", "a", "d"}},
+	}
+
+	for _, tt := range tests {
+		dst, names := SQLNamedReplacer(tt.eng, src)
+		if dst != tt.dst {
+			t.Errorf("%s: got statement\n%q\nwant\n%q", tt.eng, dst, tt.dst)
+		}
+		if strings.Join(names, ",") != strings.Join(tt.names, ",") {
+			t.Errorf("%s: got names %q, want %q", tt.eng, names, tt.names)
+		}
+	}
+
+	if dst := SQLReplacer(SQLite, "SELECT 'it''s {Q}' FROM {Q}user{Q}"); dst != `SELECT 'it''s {Q}' FROM "user"` {
+		t.Errorf("got wrong statement: %q", dst)
+	}
+	// Comments and strings in double quotes
+	commentTests := []struct {
+		eng      Engine
+		src, dst string
+	}{
+		{Postgres, "SELECT a -- it's {P}\nFROM t WHERE b = {P:b}", "SELECT a -- it's {P}\nFROM t WHERE b = $1"},
+		{Postgres, "SELECT /* it's {P} */ a FROM t WHERE b = {P}", "SELECT /* it's {P} */ a FROM t WHERE b = $1"},
+		{MySQL, `SELECT "it's {P}" FROM t WHERE b = {P:b}`, `SELECT "it's {P}" FROM t WHERE b = ?`},
+		{MySQL, `SELECT "a\"{P}" FROM t # it's {P}` + "\nWHERE b = {P}", `SELECT "a\"{P}" FROM t # it's {P}` + "\nWHERE b = ?"},
+		{MySQL, "SELECT a--{P}", "SELECT a--?"},
+		{SQLite, "SELECT a FROM t WHERE b = {P} /* {P}", "SELECT a FROM t WHERE b = ? /* {P}"},
+	}
+	for _, tt := range commentTests {
+		if dst := SQLReplacer(tt.eng, tt.src); dst != tt.dst {
+			t.Errorf("%s: got statement\n%q\nwant\n%q", tt.eng, dst, tt.dst)
+		}
+	}
+
+	if dst := SQLReplacer(Postgres, "UPDATE t SET a = {NOW} WHERE b = {P}"); dst != "UPDATE t SET a = (now() AT TIME ZONE 'UTC') WHERE b = $1" {
+		t.Errorf("got wrong statement: %q", dst)
+	}
+}
+
+func TestNamedArgs(t *testing.T) {
+	_, names := SQLNamedReplacer(MySQL, "SELECT {P:user_id}, {P:name}, {P:user_id}")
+
+	type user struct {
+		User_id int
+		Name    string // shadowed by the tag of Alias
+		Alias   string `db:"name"`
+		Ignored string `db:"-"`
+	}
+	inputs := []interface{}{
+		map[string]interface{}{"user_id": 1, "name": "a"},
+		user{1, "b", "a", "c"},
+		&user{1, "b", "a", "c"},
+	}
+
+	for _, in := range inputs {
+		args, err := NamedArgs(names, in)
+		if err != nil {
+			t.Errorf("%T: %s", in, err)
+			continue
+		}
+		if len(args) != 3 || args[0] != 1 || args[1] != "a" || args[2] != 1 {
+			t.Errorf("%T: got wrong arguments: %v", in, args)
+		}
+	}
+
+	if _, err := NamedArgs(names, map[string]interface{}{"name": "a"}); err == nil {
+		t.Error("expected to get an error by argument not found")
+	}
+	if _, err := NamedArgs([]string{"ignored"}, user{}); err == nil {
+		t.Error("expected to skip the field with tag \"-\"")
+	}
+
+	// Field of an embedded mixin
+	type audit struct {
+		CreatedBy string `db:"created_by"`
+	}
+	type note struct {
+		NoteID int `db:"note_id"`
+		audit
+	}
+	args, err := NamedArgs([]string{"created_by", "note_id"}, &note{1, audit{"a"}})
+	if err != nil || len(args) != 2 || args[0] != "a" || args[1] != 1 {
+		t.Errorf("embedded struct: got %v, %v", args, err)
+	}
+}
+
+func TestUnknownColumn(t *testing.T) {
+	if _, err := UnknownColumn("foo", "bar"); err == nil {
+		t.Error("expected to get an error by unknown column")
+	}
+
+	if v, err := UnknownColumn("foo", "bar", IgnoreUnknownColumns); err != nil || v == nil {
+		t.Errorf("expected to discard the column, got %v, %v", v, err)
+	}
+}
+
+func TestCharField(t *testing.T) {
+	var s string
+	for _, src := range []interface{}{"ab  ", []byte("ab "), "ab"} {
+		if err := (CharField{S: &s}).Scan(src); err != nil || s != "ab" {
+			t.Errorf("Scan(%q): got %q, %v; want \"ab\"", src, s, err)
+		}
+	}
+	if err := (CharField{S: &s}).Scan(nil); err != nil || s != "" {
+		t.Errorf("Scan(nil): got %q, %v", s, err)
+	}
+	if err := (CharField{S: &s}).Scan(1); err == nil {
+		t.Error("expected to get an error by a type not supported")
+	}
+}
+
+func TestStatementsPrepare(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	raw := "INSERT INTO {Q}user{Q} (a, b) VALUES({P}, {P})"
+	stmts := NewStatements(map[int]string{0: raw})
+
+	for _, eng := range []Engine{Postgres, MySQL} {
+		if err := stmts.Prepare(db, eng); err != nil {
+			t.Fatalf("%s: %s", eng, err)
+		}
+		if stmts.raw[0] != raw {
+			t.Errorf("%s: raw statement modified: %q", eng, stmts.raw[0])
+		}
+		if _, err := stmts.Get(0); err != nil {
+			t.Errorf("%s: %s", eng, err)
+		}
+	}
+
+	if err := stmts.Close(); err != nil {
+		t.Error(err)
+	}
+	if err := stmts.Close(); err != nil {
+		t.Error("expected to close several times:", err)
+	}
+	if _, err := stmts.Get(0); err != ErrNoDatabase {
+		t.Errorf("expected error %q, got %v", ErrNoDatabase, err)
+	}
+}
+
+func TestStatementsRebind(t *testing.T) {
+	db1, db2 := openFakeDB(t), openFakeDB(t)
+	defer db1.Close()
+	defer db2.Close()
+
+	stmts := NewStatements(map[int]string{0: "SELECT a FROM foo WHERE a = {P}"})
+
+	var wg sync.WaitGroup
+	for _, db := range []*sql.DB{db1, db2, db1, db2} {
+		wg.Add(1)
+		go func(db *sql.DB) {
+			defer wg.Done()
+			if err := stmts.Prepare(db, Postgres); err != nil {
+				t.Error(err)
+			}
+			if _, err := stmts.Get(0); err != nil {
+				t.Error(err)
+			}
+		}(db)
+	}
+	wg.Wait()
+
+	if err := stmts.Prepare(db2, Postgres); err != nil {
+		t.Fatal(err)
+	}
+	if stmts.db != db2 || len(stmts.stmt) != 1 {
+		t.Errorf("expected the statements prepared on the last database bound")
+	}
+	if err := stmts.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStatementsError(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	stmts := NewStatements(map[int]string{
+		0: "SELECT a FROM foo WHERE a = {P}",
+		1: "fail 1",
+		2: "fail 2",
+	})
+
+	err := stmts.Prepare(db, SQLite)
+	errPrep, ok := err.(*PrepareError)
+	if !ok {
+		t.Fatalf("expected to get a *PrepareError, got %v", err)
+	}
+	if len(errPrep.Err) != 2 {
+		t.Errorf("expected to get 2 errors, got %d: %s", len(errPrep.Err), err)
+	}
+
+	if _, err = stmts.Get(0); err != nil {
+		t.Error(err)
+	}
+	if _, err = stmts.Get(1); err == nil {
+		t.Error("expected to get an error preparing the statement")
+	}
+}
+
+func TestStatementsLazy(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	stmts := NewStatements(map[int]string{0: "SELECT a FROM foo WHERE a = {P}"})
+	if err := stmts.Bind(db, Postgres); err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts.stmt) != 0 {
+		t.Fatal("expected to have not prepared statements")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := stmts.Get(0); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stmts.query[0] != "SELECT a FROM foo WHERE a = $1" {
+		t.Errorf("got wrong statement: %q", stmts.query[0])
+	}
+	if err := stmts.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStatementsByEngine(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	stmts := NewStatementsByEngine(map[Engine]map[int]string{
+		Postgres: {0: "SELECT {P}"},
+		MySQL:    {0: "fail {P}"},
+	})
+
+	if err := stmts.Prepare(db, Postgres); err != nil {
+		t.Error(err)
+	}
+	if stmts.query[0] != "SELECT $1" {
+		t.Errorf("got wrong statement: %q", stmts.query[0])
+	}
+	if err := stmts.Prepare(db, MySQL); err == nil {
+		t.Error("expected to get the statement for MySQL")
+	}
+	if err := stmts.Prepare(db, SQLite); err == nil {
+		t.Error("expected to get an error by engine without statements")
+	}
+}
+
+func TestStatementsQuerier(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	stmts := NewStatements(map[int]string{0: "INSERT INTO foo (a) VALUES({P})"})
+
+	if _, err := stmts.ExecContext(ctx, db, 0, 1); err != ErrNoDatabase {
+		t.Errorf("expected error %q, got %v", ErrNoDatabase, err)
+	}
+	var v int
+	if err := stmts.QueryRowContext(ctx, db, 0, 1).Scan(&v); err != ErrNoDatabase {
+		t.Errorf("QueryRowContext: expected error %q, got %v", ErrNoDatabase, err)
+	}
+	if err := stmts.Bind(db, Postgres); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for _, q := range []Querier{db, tx, conn} {
+		if _, err = stmts.ExecContext(ctx, q, 0, 1); err != nil {
+			t.Errorf("%T: %s", q, err)
+		}
+		rows, err := stmts.QueryContext(ctx, q, 0, 1)
+		if err != nil {
+			t.Errorf("%T: %s", q, err)
+			continue
+		}
+		rows.Close()
+	}
+	if err = tx.Commit(); err != nil {
+		t.Error(err)
+	}
+
+	if _, err = stmts.ExecContext(ctx, db, 1); err == nil {
+		t.Error("expected to get an error by statement not found")
+	}
+}
+
+// * * *
+
+func TestStatementsTx(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	stmts := NewStatements(map[int]string{0: "SELECT a FROM foo WHERE a = {P}"})
+	if err := stmts.Prepare(db, Postgres); err != nil {
+		t.Fatal(err)
+	}
+	defer stmts.Close()
+
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	if _, err = stmts.ExecContext(ctx, tx, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := stmts.QueryContext(ctx, tx, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+
+	row := stmts.QueryRowContext(ctx, tx, 0, 1)
+	if row.stmt == nil {
+		t.Fatal("expected a statement bound to the transaction")
+	}
+	var v int
+	if err = row.Scan(&v); err != sql.ErrNoRows {
+		t.Fatal(err)
+	}
+	if _, err = row.stmt.ExecContext(ctx, 1); err == nil {
+		t.Error("expected the statement of the transaction closed after of scanning")
+	}
+}
+
+// fakeDriver is a SQL driver which prepares every statement unless it starts
+// with "fail".
+
+func init() {
+	sql.Register("modsql_fake", fakeDriver{})
+}
+
+func openFakeDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("modsql_fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	return fakeConn{failRollback: name == "fail-rollback"}, nil
+}
+
+type fakeConn struct{ failRollback bool }
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	if strings.HasPrefix(query, "fail") {
+		return nil, errors.New("syntax error: " + query)
+	}
+	return fakeStmt{}, nil
+}
+
+func (fakeConn) Close() error                { return nil }
+func (c fakeConn) Begin() (driver.Tx, error) { return fakeTx{c.failRollback}, nil }
+
+type fakeTx struct{ failRollback bool }
+
+func (fakeTx) Commit() error { return nil }
+
+func (tx fakeTx) Rollback() error {
+	if tx.failRollback {
+		return errors.New("rollback failed")
+	}
+	return nil
+}
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error) { return fakeRows{}, nil }
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string              { return nil }
+func (fakeRows) Close() error                   { return nil }
+func (fakeRows) Next(dest []driver.Value) error { return io.EOF }
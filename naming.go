@@ -0,0 +1,437 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"log"
+	"strings"
+	"unicode"
+)
+
+// Initialisms are the words which CamelName writes in upper case.
+var Initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true,
+	"DNS": true, "EOF": true, "GUID": true, "HTML": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "QPS": true,
+	"RAM": true, "RPC": true, "SLA": true, "SMTP": true, "SQL": true,
+	"SSH": true, "TCP": true, "TLS": true, "TTL": true, "UDP": true,
+	"UI": true, "UID": true, "UUID": true, "URI": true, "URL": true,
+	"UTF8": true, "VM": true, "XML": true, "XMPP": true, "XSRF": true,
+	"XSS": true,
+}
+
+// TitleName returns the name with its first letter in upper case, like
+// strings.Title. It is the naming strategy used by default.
+func TitleName(name string) string { return strings.Title(name) }
+
+// CamelName returns the name, in snake case or camel case, converted to camel
+// case with the initialisms in upper case, i.e. "user_id" and "userId" are
+// converted to "UserID", and "int8_" to "Int8".
+func CamelName(name string) string {
+	var words []string
+	word := []rune{}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' {
+			if len(word) != 0 {
+				words = append(words, string(word))
+				word = word[:0]
+			}
+			continue
+		}
+
+		// A new word starts in an upper letter after of a lower letter or a
+		// digit, or before of a lower letter after of several upper ones.
+		if unicode.IsUpper(r) && len(word) != 0 {
+			prev := word[len(word)-1]
+			if !unicode.IsUpper(prev) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				words = append(words, string(word))
+				word = word[:0]
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) != 0 {
+		words = append(words, string(word))
+	}
+	if len(words) == 0 {
+		return name
+	}
+
+	for i, w := range words {
+		if upper := strings.ToUpper(w); Initialisms[upper] {
+			words[i] = upper
+		} else {
+			words[i] = strings.Title(strings.ToLower(w))
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// NameStrategy sets the function to get the names of the Go types and fields
+// from the names of the tables and columns; by default, it is TitleName.
+// The names set through GoName are not changed.
+func (md *metadata) NameStrategy(fn func(name string) string) *metadata {
+	md.naming = fn
+	return md
+}
+
+// OmitEmpty adds the option "omitempty" to the tag "json" of all fields.
+func (md *metadata) OmitEmpty() *metadata {
+	md.omitEmpty = true
+	return md
+}
+
+// RenameCollisions renames the Go identifiers which are not valid or which
+// collide with other ones, instead of failing at creating the model. The names
+// are changed adding "_" at the end, or, for the constants of an enumeration,
+// using the full name of the table as prefix.
+func (md *metadata) RenameCollisions() *metadata {
+	md.rename = true
+	return md
+}
+
+// GoName sets the name of the Go type of the table.
+func (t *table) GoName(name string) {
+	t.goName = name
+}
+
+// GoName sets the name of the field for the column in the Go type.
+func (c *column) GoName(name string) *column {
+	c.goName = name
+	return c
+}
+
+// OmitEmpty adds the option "omitempty" to the tag "json" of the field.
+func (c *column) OmitEmpty() *column {
+	c.omitEmpty = true
+	return c
+}
+
+// typeName returns the name of the Go type of the table.
+func (t *table) typeName() string {
+	if t.goName != "" {
+		return t.goName
+	}
+	return t.meta.naming(t.Name)
+}
+
+// fieldName returns the name of the field for the column.
+func (t *table) fieldName(name string) string {
+	if c := t.column(name); c != nil && c.goName != "" {
+		return c.goName
+	}
+	return t.meta.naming(name)
+}
+
+// fieldArg returns the argument of the field of the column, to insert and to
+// scan it.
+func (t *table) fieldArg(c *column) string {
+	if c.type_ == Char {
+		return fmt.Sprintf("modsql.CharField{S: &t.%s}", t.fieldName(c.Name))
+	}
+	return "&t." + t.fieldName(c.Name)
+}
+
+// fieldTags returns the tags of the field for the column.
+func (t *table) fieldTags(c *column) string {
+	json := c.Name
+	if c.omitEmpty || t.meta.omitEmpty {
+		json += ",omitempty"
+	}
+	return "`db:\"" + c.Name + "\" json:\"" + json + "\"`"
+}
+
+// typeMethods are the names of the methods generated for the Go types of the
+// tables, out of the ones to get the related rows.
+var typeMethods = []string{
+	"Args", "StmtInsert", "Insert", "Columns", "ScanColumns", "Scan",
+	"StmtUpsert", "Upsert", "Delete", "Exists", "Update", "DeleteCascade",
+	"Validate",
+}
+
+// packageNames are the names declared in the Go file generated, out of the
+// ones got from the tables.
+var packageNames = []string{
+	"ENGINE", "Insert", "Upsert", "SelectByPK", "Update", "Delete", "Exists",
+	"SelectRelated", "Relations", "EnumTables", "SyncEnums", "init",
+	"context", "driver", "fmt", "modsql", "sql", "strconv", "strings", "time", // imports
+}
+
+// checkNames checks that the Go identifiers got from the tables, columns and
+// values of enumerations are valid and do not collide; every problem is
+// reported, unless the identifiers are renamed through RenameCollisions.
+func (md *metadata) checkNames() {
+	var errs []string
+
+	// check returns a name which is valid and which is not taken.
+	check := func(what, name string, taken func(string) bool) string {
+		problem := ""
+		switch {
+		case token.IsKeyword(name):
+			problem = "is a Go keyword"
+		case !token.IsIdentifier(name):
+			problem = "is not a valid Go identifier"
+		case taken(name):
+			problem = "collides with other Go identifier"
+		default:
+			return name
+		}
+		if !md.rename {
+			errs = append(errs, fmt.Sprintf("%s: name %q %s", what, name, problem))
+			return name
+		}
+
+		newName := validIdentifier(name)
+		for token.IsKeyword(newName) || taken(newName) {
+			newName += "_"
+		}
+		log.Printf("%s: name %q renamed to %q", what, name, newName)
+		return newName
+	}
+
+	used := make(map[string]bool)
+	for _, v := range packageNames {
+		used[v] = true
+	}
+
+	// == Embedded mixins
+	for _, m := range md.mixins {
+		name := m.typeName(md)
+		newName := check(fmt.Sprintf("mixin %q", m.Name), name, func(s string) bool {
+			return used[s]
+		})
+		if newName != name {
+			m.goName = newName
+		}
+		used[newName] = true
+	}
+
+	for _, t := range md.tables {
+		if t.isEnum {
+			name := t.typeName()
+			newName := check(fmt.Sprintf("enumeration %q", t.Name), name, func(s string) bool {
+				return used[s] || used["Parse"+s] || used[s+"Attr"] || used[s+"Attrs"]
+			})
+			if newName != name {
+				t.goName = newName
+			}
+			used[newName] = true
+			used["Parse"+newName] = true
+			used[t.enumNamesVar()] = true
+			if len(t.Columns) > 2 {
+				used[newName+"Attr"] = true
+				used[newName+"Attrs"] = true
+			}
+
+			// == Fields of the attributes
+			fields := make(map[string]bool)
+			for i := 2; i < len(t.Columns); i++ {
+				col := &t.Columns[i]
+				name := t.fieldName(col.Name)
+
+				newName := check(fmt.Sprintf("table %q: column %q", t.Name, col.Name),
+					name, func(s string) bool { return fields[s] })
+				if newName != name {
+					col.goName = newName
+				}
+				fields[newName] = true
+			}
+			continue
+		}
+
+		// == Fields
+		fields := make(map[string]bool)
+		for _, v := range typeMethods {
+			fields[v] = true
+		}
+
+		// The fields of the embedded mixins can not be renamed since they
+		// are shared by several tables.
+		embedded := make(map[*mixin]bool)
+		for _, col := range t.Columns {
+			if col.mixin == nil {
+				continue
+			}
+			names := []string{t.fieldName(col.Name)}
+			if !embedded[col.mixin] {
+				embedded[col.mixin] = true
+				names = append(names, col.mixin.typeName(md))
+			}
+
+			for _, name := range names {
+				if fields[name] {
+					errs = append(errs, fmt.Sprintf("table %q: mixin %q: name %q collides with other Go identifier",
+						t.Name, col.mixin.Name, name))
+				}
+				fields[name] = true
+			}
+		}
+
+		for i := range t.Columns {
+			col := &t.Columns[i]
+			if col.mixin != nil {
+				continue
+			}
+			name := t.fieldName(col.Name)
+
+			newName := check(fmt.Sprintf("table %q: column %q", t.Name, col.Name),
+				name, func(s string) bool { return fields[s] })
+			if newName != name {
+				col.goName = newName
+			}
+			fields[newName] = true
+		}
+
+		// == Type, and functions named from it
+		name := t.typeName()
+		newName := check(fmt.Sprintf("table %q", t.Name), name, func(s string) bool {
+			return used[s] || used["BatchInsert"+s] || used["Get"+s+"ByPK"]
+		})
+		if newName != name {
+			t.goName = newName
+		}
+		used[newName] = true
+		used["BatchInsert"+newName] = true
+		used["Get"+newName+"ByPK"] = true
+	}
+
+	// == Native enumerations
+	for _, e := range md.nativeEnums {
+		name := e.typeName()
+		newName := check(fmt.Sprintf("native enumeration %q", e.Name), name, func(s string) bool {
+			return used[s] || used["Parse"+s]
+		})
+		if newName != name {
+			e.goName = newName
+		}
+		used[newName] = true
+		used["Parse"+newName] = true
+
+		e.constNames = e.constants()
+		for i, name := range e.constNames {
+			e.constNames[i] = check(fmt.Sprintf("native enumeration %q", e.Name),
+				name, func(s string) bool { return used[s] })
+			used[e.constNames[i]] = true
+		}
+	}
+
+	// == Constants of enumerations
+	for _, t := range md.tables {
+		if !t.isEnum {
+			continue
+		}
+		t.constNames = t.enumConstants()
+
+		if md.rename {
+			// Try the full name of the table like prefix before of renaming
+			// every constant.
+			for _, v := range t.constNames {
+				if used[v] {
+					prefix := strings.ToUpper(t.Name) + "_"
+					for i, data := range t.data {
+						t.constNames[i] = prefix + strings.ToUpper(data[1].(string))
+					}
+					break
+				}
+			}
+		}
+
+		for i, name := range t.constNames {
+			t.constNames[i] = check(fmt.Sprintf("enumeration %q", t.Name),
+				name, func(s string) bool { return used[s] })
+			used[t.constNames[i]] = true
+		}
+	}
+
+	if len(errs) != 0 {
+		log.Fatalf("collisions of Go identifiers (use RenameCollisions to rename them):\n%s",
+			strings.Join(errs, "\n"))
+	}
+}
+
+// enumConstants returns the names of the constants of an enumeration.
+func (t *table) enumConstants() []string {
+	if len(t.constNames) != 0 {
+		return t.constNames
+	}
+
+	prefix := enumPrefix(t.Name)
+	names := make([]string, len(t.data))
+	for i, v := range t.data {
+		names[i] = prefix + strings.ToUpper(v[1].(string))
+	}
+	return names
+}
+
+// enumPrefix returns the prefix of the constants of an enumeration: the first
+// part of its name, until '_' or an upper letter.
+func enumPrefix(name string) string {
+	prefix := name
+	for i, letter := range name[1:] {
+		if unicode.IsUpper(letter) || letter == '_' {
+			prefix = name[:i+1]
+			break
+		}
+	}
+	return strings.ToUpper(prefix) + "_"
+}
+
+// validIdentifier returns the name with the characters which are not valid in
+// a Go identifier replaced by '_'; a digit at the beginning is prefixed by 'X'.
+func validIdentifier(name string) string {
+	if name == "" {
+		return "X"
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			runes[i] = '_'
+		}
+	}
+	if unicode.IsDigit(runes[0]) {
+		return "X" + string(runes)
+	}
+	return string(runes)
+}
+
+// paramName returns the name of a parameter for the field, with the first
+// word in lower case; "_" is added to the Go keywords, to the predeclared
+// identifiers and to the names of the other parameters, which are in used.
+// The name returned is added to used, if it is not nil.
+func paramName(field string, used map[string]bool) string {
+	runes := []rune(field)
+
+	n := 0 // upper letters at the beginning
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	if n > 1 && n < len(runes) && unicode.IsLower(runes[n]) {
+		n-- // the last one starts other word, as in "URLPath"
+	}
+	for i := 0; i < n; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	name := string(runes)
+	if token.IsKeyword(name) || types.Universe.Lookup(name) != nil || name == "ctx" || name == "q" {
+		name += "_"
+	}
+	for used[name] {
+		name += "_"
+	}
+
+	if used != nil {
+		used[name] = true
+	}
+	return name
+}
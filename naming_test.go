@@ -0,0 +1,82 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCamelName(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"user_id", "UserID"},
+		{"userId", "UserID"},
+		{"typeId", "TypeID"},
+		{"default_value", "DefaultValue"},
+		{"int8_", "Int8"},
+		{"float32_", "Float32"},
+		{"mp3", "Mp3"},
+		{"api_url", "APIURL"},
+		{"HTTPServer", "HTTPServer"},
+		{"utf8_name", "UTF8Name"},
+		{"_", "_"},
+	}
+
+	for _, tt := range tests {
+		if got := CamelName(tt.in); got != tt.out {
+			t.Errorf("CamelName(%q): got %q, want %q", tt.in, got, tt.out)
+		}
+	}
+}
+
+func TestCheckNames(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	resetColumnsErr(t)
+
+	md := Metadata("model", SQLite).RenameCollisions()
+
+	Enum("sex", md, Int8, 0, "female", "male")
+	Enum("sex_type", md, Int8, 0, "female", "not applicable")
+
+	Table("insert", md,
+		Column("type", Int).PrimaryKey(),
+		Column("Type", Int),
+		Column("scan", String),
+		Column("3d", Bool),
+	)
+	md.checkNames()
+
+	tests := []struct{ got, want string }{
+		{md.tables[2].typeName(), "Insert_"},
+		{md.tables[2].fieldName("type"), "Type"},
+		{md.tables[2].fieldName("Type"), "Type_"},
+		{md.tables[2].fieldName("scan"), "Scan_"},
+		{md.tables[2].fieldName("3d"), "X3d"},
+		{strings.Join(md.tables[0].enumConstants(), " "), "SEX_FEMALE SEX_MALE"},
+		{strings.Join(md.tables[1].enumConstants(), " "), "SEX_TYPE_FEMALE SEX_TYPE_NOT_APPLICABLE"},
+		{paramName("BookID", nil), "bookID"},
+		{paramName("ID", nil), "id"},
+		{paramName("URLPath", nil), "urlPath"},
+		{paramName("Type", nil), "type_"},
+		{paramName("Int", nil), "int_"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+
+	// Fields whose parameters have the same name
+	used := make(map[string]bool)
+	if p1, p2 := paramName("URLPath", used), paramName("UrlPath", used); p1 != "urlPath" || p2 != "urlPath_" {
+		t.Errorf("got parameters %q and %q", p1, p2)
+	}
+}
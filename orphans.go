@@ -0,0 +1,100 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Orphans represents the rows of a table whose foreign key references a row
+// which does not exist, like it happens when the foreign keys are not checked
+// (SQLite by default, or MySQL with FOREIGN_KEY_CHECKS=0).
+type Orphans struct {
+	Relation
+	Keys [][]interface{} // values of the foreign key, without duplicates
+}
+
+func (o Orphans) String() string {
+	keys := make([]string, len(o.Keys))
+	for i, key := range o.Keys {
+		values := make([]string, len(key))
+		for j, v := range key {
+			if b, ok := v.([]byte); ok {
+				v = string(b)
+			}
+			values[j] = fmt.Sprint(v)
+		}
+
+		keys[i] = strings.Join(values, ", ")
+		if len(key) > 1 {
+			keys[i] = "(" + keys[i] + ")"
+		}
+	}
+	return fmt.Sprintf("%s (%s) -> %s (%s): %d orphan keys: %s",
+		o.Table, strings.Join(o.Columns, ", "), o.RefTable, strings.Join(o.RefColumns, ", "),
+		len(o.Keys), strings.Join(keys, ", "))
+}
+
+// CheckOrphans checks the referential integrity of every relation, returning
+// the relations which have orphan rows.
+func CheckOrphans(ctx context.Context, q Querier, eng Engine, rels []Relation) ([]Orphans, error) {
+	var res []Orphans
+
+	for _, r := range rels {
+		rows, err := q.QueryContext(ctx, SQLReplacer(eng, orphansQuery(r)))
+		if err != nil {
+			return nil, fmt.Errorf("table %q: %s", r.Table, err)
+		}
+
+		o := Orphans{Relation: r}
+		for rows.Next() {
+			key := make([]interface{}, len(r.Columns))
+			dest := make([]interface{}, len(key))
+			for i := range key {
+				dest[i] = &key[i]
+			}
+			if err = rows.Scan(dest...); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			o.Keys = append(o.Keys, key)
+		}
+		if err = rows.Close(); err != nil {
+			return nil, err
+		}
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+
+		if len(o.Keys) != 0 {
+			res = append(res, o)
+		}
+	}
+	return res, nil
+}
+
+// orphansQuery returns the query to get the values of the foreign key in the
+// rows which reference a row that does not exist. The rows with some NULL value
+// in the foreign key are skipped, since they do not reference any row.
+func orphansQuery(r Relation) string {
+	columns := make([]string, len(r.Columns))
+	on := make([]string, len(r.Columns))
+	where := make([]string, len(r.Columns)+1)
+
+	for i, v := range r.Columns {
+		columns[i] = "c." + quoteStatementSQL(v)
+		on[i] = fmt.Sprintf("p.%s = c.%s", quoteStatementSQL(r.RefColumns[i]), quoteStatementSQL(v))
+		where[i+1] = columns[i] + " IS NOT NULL"
+	}
+	where[0] = "p." + quoteStatementSQL(r.RefColumns[0]) + " IS NULL"
+
+	return fmt.Sprintf("SELECT DISTINCT %s FROM %s c LEFT JOIN %s p ON %s WHERE %s",
+		strings.Join(columns, ", "), quoteStatementSQL(r.Table), quoteStatementSQL(r.RefTable),
+		strings.Join(on, " AND "), strings.Join(where, " AND "))
+}
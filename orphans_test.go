@@ -0,0 +1,56 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOrphansQuery(t *testing.T) {
+	tests := []struct {
+		rel  Relation
+		want string
+	}{
+		{
+			Relation{"chapter", []string{"book_fk"}, "book", []string{"book_id"}},
+			"SELECT DISTINCT c.book_fk FROM chapter c LEFT JOIN book p ON p.book_id = c.book_fk " +
+				"WHERE p.book_id IS NULL AND c.book_fk IS NOT NULL",
+		},
+		{
+			Relation{"sub_account", []string{"ref_num", "ref_type"}, "account", []string{"acc_num", "acc_type"}},
+			"SELECT DISTINCT c.ref_num, c.ref_type FROM sub_account c LEFT JOIN account p " +
+				"ON p.acc_num = c.ref_num AND p.acc_type = c.ref_type " +
+				"WHERE p.acc_num IS NULL AND c.ref_num IS NOT NULL AND c.ref_type IS NOT NULL",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := orphansQuery(tt.rel); got != tt.want {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.rel.Table, got, tt.want)
+		}
+	}
+
+	o := Orphans{tests[1].rel, [][]interface{}{{1, []byte("a")}, {2, []byte("b")}}}
+	if got, want := o.String(), "sub_account (ref_num, ref_type) -> account (acc_num, acc_type): "+
+		"2 orphan keys: (1, a), (2, b)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestCheckOrphans(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	res, err := CheckOrphans(context.Background(), db, SQLite, testRelations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 0 {
+		t.Errorf("expected to get no orphans, got %v", res)
+	}
+}
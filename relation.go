@@ -0,0 +1,76 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+)
+
+// QueryIn runs the query for every one of the given values, adding the clause
+// "IN" at the end of the query, so it has to finish with the column to compare.
+// The values are deduplicated and split in several queries, according to the
+// maximum number of bind parameters of the engine; fn is called for every row.
+// It is to be called from the Go code generated.
+func QueryIn(ctx context.Context, q Querier, eng Engine, query string,
+	values []interface{}, fn func(rows *sql.Rows) error) error {
+
+	queries, args := splitIn(eng, query, values)
+
+	for i, query := range queries {
+		rows, err := q.QueryContext(ctx, query, args[i]...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			if err = fn(rows); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		if err = rows.Close(); err != nil {
+			return err
+		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitIn returns the queries with the clause "IN", and their arguments,
+// to select the values without duplicates.
+func splitIn(eng Engine, query string, values []interface{}) (queries []string, args [][]interface{}) {
+	unique := make([]interface{}, 0, len(values))
+	seen := make(map[interface{}]bool, len(values))
+
+	for _, v := range values {
+		if v != nil && reflect.TypeOf(v).Comparable() {
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+		}
+		unique = append(unique, v)
+	}
+
+	maxParams := MaxParams[eng]
+	for len(unique) != 0 {
+		n := len(unique)
+		if n > maxParams {
+			n = maxParams
+		}
+
+		in := " IN (" + strings.Repeat("{P}, ", n-1) + "{P})"
+		queries = append(queries, SQLReplacer(eng, query+in))
+		args = append(args, unique[:n])
+		unique = unique[n:]
+	}
+	return
+}
@@ -0,0 +1,48 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestSplitIn(t *testing.T) {
+	queries, args := splitIn(Postgres, "SELECT a FROM t WHERE b", []interface{}{1, 2, 1, 3})
+
+	if len(queries) != 1 || queries[0] != "SELECT a FROM t WHERE b IN ($1, $2, $3)" {
+		t.Errorf("got queries %q", queries)
+	}
+	if len(args) != 1 || len(args[0]) != 3 {
+		t.Errorf("expected to get 3 values without duplicates, got %v", args)
+	}
+
+	values := make([]interface{}, 2000)
+	for i := range values {
+		values[i] = i
+	}
+	queries, args = splitIn(SQLite, "SELECT a FROM t WHERE b", values)
+
+	if len(queries) != 3 || len(args[0]) != 999 || len(args[2]) != 2 {
+		t.Errorf("got %d queries, want 3 with at most 999 values", len(queries))
+	}
+	if queries, _ = splitIn(MySQL, "SELECT a FROM t WHERE b", nil); len(queries) != 0 {
+		t.Errorf("expected to get no query without values, got %q", queries)
+	}
+}
+
+func TestQueryIn(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	err := QueryIn(context.Background(), db, SQLite, "SELECT a FROM t WHERE b",
+		[]interface{}{1, 2}, func(*sql.Rows) error { return nil })
+	if err != nil {
+		t.Error(err)
+	}
+}
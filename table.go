@@ -17,31 +17,30 @@ type ForeignColumn struct {
 	Foreign string
 }
 
-type fkConstraint struct {
-	table string
-	src   []string
-	dst   []string
-}
-
-type compoIndex struct {
-	isUnique bool
-	index    []string
-}
-
 type table struct {
-	isEnum    bool // table with list of permitted values that are enumerated
-	startEnum int
+	isEnum     bool // table with list of permitted values that are enumerated
+	timestamps bool // with columns "created_at" and "updated_at"
+	softDelete bool // with column "deleted_at"
+	startEnum  int
+	constNames []string // names of the constants for the enumeration
 
 	Name    string
 	sqlName string
+	goName  string
+	comment string
 	meta    *metadata
 	Columns []column
 
 	// Constraints and indexes to table level
-	uniqueCons []string
-	pkCons     []string
-	fkCons     []fkConstraint
-	index      []compoIndex
+	uniqueCons []*constraint
+	pkCons     *constraint
+	fkCons     []*constraint
+	checkCons  []*constraint
+	index      []*compoIndex
+
+	// To update at inserting a row whose key already exists
+	upsertKey    []string
+	upsertUpdate []string
 
 	// To insert values
 	data     [][]interface{}
@@ -49,21 +48,66 @@ type table struct {
 }
 
 // Enum defines a table for enumeration values starting from start.
-func Enum(name string, meta *metadata, intType sqlType, start int, value ...string) {
+// The names of the values are unique.
+func Enum(name string, meta *metadata, intType sqlType, start int, value ...string) *table {
 	if intType < Int || intType > Int64 {
 		log.Fatalf("wrong type for integer: %s", intType.goString())
 	}
 
 	t := Table(name, meta,
 		Column("id", intType).PrimaryKey(),
-		Column("name", String),
+		Column("name", String).Unique(),
 	)
 	t.isEnum = true
 	t.startEnum = start
 
-	for i, v := range value {
-		t.Insert(i+start, v)
+	for _, v := range value {
+		t.Value(v)
+	}
+	return t
+}
+
+// Attrs adds columns with extra attributes to a table of enumeration, like a
+// description or a sort order. They have to be added before of the values.
+func (t *table) Attrs(col ...*column) *table {
+	if !t.isEnum {
+		log.Fatalf("table %q: Attrs(): it is not a table of enumeration", t.Name)
 	}
+	if len(t.data) != 0 {
+		log.Fatalf("table %q: Attrs(): the attributes have to be added before of the values", t.Name)
+	}
+
+	for _, v := range col {
+		if t.column(v.Name) != nil {
+			log.Fatalf("table %q: Attrs(): column %q already exists", t.Name, v.Name)
+		}
+		if v.cons&(primaryKey|foreignKey) != 0 {
+			log.Fatalf("table %q: Attrs(): column %q can not be a key", t.Name, v.Name)
+		}
+		t.Columns = append(t.Columns, *v)
+	}
+	return t
+}
+
+// Value adds a value to a table of enumeration, with the values for the
+// extra attributes, in the same order as they were added.
+func (t *table) Value(name string, attr ...interface{}) *table {
+	if !t.isEnum {
+		log.Fatalf("table %q: Value(): it is not a table of enumeration", t.Name)
+	}
+	if len(attr) != len(t.Columns)-2 {
+		log.Fatalf("table %q: Value(%q): incorrect number of attributes: have %d, want %d",
+			t.Name, name, len(attr), len(t.Columns)-2)
+	}
+
+	for _, v := range t.data {
+		if v[1] == name {
+			log.Fatalf("table %q: Value(%q): name already exists", t.Name, name)
+		}
+	}
+
+	t.Insert(append([]interface{}{len(t.data) + t.startEnum, name}, attr...)...)
+	return t
 }
 
 // Table defines a new table.
@@ -88,9 +132,9 @@ func Table(name string, meta *metadata, col ...*column) *table {
 
 // Insert generates SQL statements to insert values.
 func (t *table) Insert(a ...interface{}) {
-	if len(a) != len(t.Columns) {
+	if n := len(t.insertColumns()); len(a) != n {
 		log.Fatalf("incorrect number of arguments to insert in table %q: have %d, want %d",
-			t.Name, len(a), len(t.Columns))
+			t.Name, len(a), n)
 	}
 
 	vec := make([]interface{}, 0)
@@ -105,9 +149,9 @@ func (t *table) Insert(a ...interface{}) {
 // InsertTestData generates SQL statements to insert values in test database.
 // It is generated in file names with suffix "_test".
 func (t *table) InsertTestData(a ...interface{}) {
-	if len(a) != len(t.Columns) {
+	if n := len(t.insertColumns()); len(a) != n {
 		log.Fatalf("incorrect number of arguments to insert test data in table %q: have %d, want %d",
-			t.Name, len(a), len(t.Columns))
+			t.Name, len(a), n)
 	}
 
 	vec := make([]interface{}, 0)
@@ -122,7 +166,7 @@ func (t *table) InsertTestData(a ...interface{}) {
 // ForeignKey creates explicit/composite foreign key constraint.
 // The keys in the map are the columns of this table, and the values are the
 // foreign columns of the given table.
-func (t *table) ForeignKey(table string, columns ...ForeignColumn) {
+func (t *table) ForeignKey(table string, columns ...ForeignColumn) *constraint {
 	if table == t.Name {
 		log.Fatalf("table %q: ForeignKey(): given foreign table can not have "+
 			"the same name than actual table", table)
@@ -143,16 +187,16 @@ func (t *table) ForeignKey(table string, columns ...ForeignColumn) {
 			t.Name, table)
 	}
 
-	var fk fkConstraint
+	fk := &constraint{kind: foreignKey, table: t, fkTable: table}
 
 	for _, col := range columns {
-		fk.src = append(fk.src, col.Local)
-		fk.dst = append(fk.dst, col.Foreign)
+		fk.columns = append(fk.columns, col.Local)
+		fk.fkColumns = append(fk.fkColumns, col.Foreign)
 	}
 
-	t.existColumns("ForeignKey", fk.src)
+	t.existColumns("ForeignKey", fk.columns)
 
-	for _, c := range fk.dst {
+	for _, c := range fk.fkColumns {
 		found = false
 
 		for _, tc := range tableColumns {
@@ -167,26 +211,45 @@ func (t *table) ForeignKey(table string, columns ...ForeignColumn) {
 		}
 	}
 
-	fk.table = table
 	t.fkCons = append(t.fkCons, fk)
+	return fk
 }
 
 // PrimaryKey creates explicit/composite primary key constraint.
-func (t *table) PrimaryKey(columns ...string) {
+func (t *table) PrimaryKey(columns ...string) *constraint {
 	t.existColumns("PrimaryKey", columns)
-	t.pkCons = columns
-}
+	if t.pkCons != nil {
+		log.Fatalf("table %q: PrimaryKey(): the primary key is already defined", t.Name)
+	}
 
-// Index creates an index on a group of columns.
-func (t *table) Index(unique bool, columns ...string) {
-	t.existColumns("Index", columns)
-	t.index = append(t.index, compoIndex{unique, columns})
+	t.pkCons = &constraint{kind: primaryKey, table: t, columns: columns}
+	return t.pkCons
 }
 
 // Unique creates explicit/composite unique constraint.
-func (t *table) Unique(columns ...string) {
+// It can be called several times to add more constraints.
+func (t *table) Unique(columns ...string) *constraint {
 	t.existColumns("Unique", columns)
-	t.uniqueCons = columns
+	for _, v := range t.uniqueCons {
+		if equalNames(v.columns, columns) {
+			log.Fatalf("table %q: Unique(): the columns %q are already unique", t.Name, columns)
+		}
+	}
+
+	c := &constraint{kind: uniqueCons, table: t, columns: columns}
+	t.uniqueCons = append(t.uniqueCons, c)
+	return c
+}
+
+// Upsert sets the columns of the unique key used to update a row at inserting
+// it, when that key already exists; by default, it is used the primary key.
+// The columns to update can be listed; by default, they are all columns out
+// of the key.
+func (t *table) Upsert(key []string, update ...string) {
+	t.existColumns("Upsert", key)
+	t.existColumns("Upsert", update)
+	t.upsertKey = key
+	t.upsertUpdate = update
 }
 
 // * * *
@@ -207,3 +270,98 @@ func (t *table) existColumns(funcName string, columns []string) {
 		}
 	}
 }
+
+// column returns the column with the given name, or nil if it does not exist.
+func (t *table) column(name string) *column {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// inIndex reports whether the column is in some index at table level, without
+// the length of its prefix.
+func (t *table) inIndex(name string) bool {
+	for _, idx := range t.index {
+		for _, v := range idx.index {
+			if v == name && idx.prefix[v] == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// primaryKey returns the names of the columns in the primary key, set at
+// column or table level.
+func (t *table) primaryKey() []string {
+	if t.pkCons != nil {
+		return t.pkCons.columns
+	}
+	for _, c := range t.Columns {
+		if c.cons&primaryKey != 0 {
+			return []string{c.Name}
+		}
+	}
+	return nil
+}
+
+// isPrimaryKey reports whether the column is part of the primary key.
+func (t *table) isPrimaryKey(name string) bool {
+	for _, v := range t.primaryKey() {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueKeys returns the columns of the primary key, the unique constraints
+// and the unique indexes built on all rows.
+func (t *table) uniqueKeys() [][]string {
+	var keys [][]string
+
+	if pk := t.primaryKey(); len(pk) != 0 {
+		keys = append(keys, pk)
+	}
+	for _, c := range t.Columns {
+		if c.cons&uniqueCons != 0 || c.index == uniqIndex {
+			keys = append(keys, []string{c.Name})
+		}
+	}
+	for _, v := range t.uniqueCons {
+		keys = append(keys, v.columns)
+	}
+	for _, v := range t.index {
+		if v.isUnique && v.isFull() {
+			keys = append(keys, v.index)
+		}
+	}
+	return keys
+}
+
+// isUniqueKey reports whether the columns have a primary key or unique
+// constraint, or an unique index.
+func (t *table) isUniqueKey(columns []string) bool {
+	for _, v := range t.uniqueKeys() {
+		if equalNames(columns, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalNames reports whether both lists have the same names, in the same order.
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -3,9 +3,12 @@
 SET FOREIGN_KEY_CHECKS=0;
 
 DROP TABLE sex;
+DROP TABLE status;
+DROP TABLE person;
 DROP TABLE types;
 DROP TABLE default_value;
 DROP TABLE times;
+DROP TABLE note;
 DROP TABLE account;
 DROP TABLE sub_account;
 DROP TABLE catalog;
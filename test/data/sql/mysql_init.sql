@@ -3,7 +3,21 @@
 
 CREATE TABLE sex (
 	id   TINYINT PRIMARY KEY,
-	name TEXT
+	name VARCHAR(255) UNIQUE
+);
+
+CREATE TABLE status (
+	id         TINYINT PRIMARY KEY,
+	name       VARCHAR(255) UNIQUE,
+	label      TEXT,
+	sort_order {{.MySQLInt}},
+	active     BOOL
+);
+
+CREATE TABLE person (
+	person_id {{.MySQLInt}} PRIMARY KEY,
+	sex       TINYINT REFERENCES sex(id),
+	mood      ENUM('sad', 'ok', 'happy')
 );
 
 CREATE TABLE types (
@@ -20,7 +34,8 @@ CREATE TABLE types (
 	rune_    INT,
 	bool_    BOOL,
 
-	UNIQUE (float32_, float64_)
+	CONSTRAINT types_float32__float64__key UNIQUE (float32_, float64_),
+	CONSTRAINT types_byte__rune__key UNIQUE (byte_, rune_)
 );
 CREATE UNIQUE INDEX idx_types_float64_ ON types (float64_);
 CREATE INDEX idx_types_rune_ ON types (rune_);
@@ -30,8 +45,8 @@ CREATE TABLE default_value (
 	id       {{.MySQLInt}} PRIMARY KEY,
 	int8_    TINYINT DEFAULT 55,
 	float32_ FLOAT DEFAULT 10.2,
-	string_  TEXT,
-	binary_  BLOB,
+	string_  VARCHAR(255) DEFAULT 'it''s',
+	binary_  VARBINARY(255) DEFAULT X'6162',
 	byte_    SMALLINT DEFAULT 98,
 	rune_    INT DEFAULT 114,
 	bool_    BOOL DEFAULT FALSE
@@ -39,15 +54,30 @@ CREATE TABLE default_value (
 
 CREATE TABLE times (
 	typeId   {{.MySQLInt}},
-	datetime TIMESTAMP
+	datetime TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE note (
+	note_id    {{.MySQLInt}} PRIMARY KEY,
+	body       TEXT COMMENT 'Text written by the user, in Markdown; it''s not sanitized.',
+	tenant_id  {{.MySQLInt}},
+	created_by TEXT,
+	updated_by TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+) COMMENT='Notes attached to the tenant.';
+CREATE INDEX idx_note__m1 ON note (tenant_id);
+CREATE INDEX idx_note_recent ON note (tenant_id, created_at DESC);
+
 CREATE TABLE account (
 	acc_num   {{.MySQLInt}},
 	acc_type  {{.MySQLInt}},
-	acc_descr TEXT,
+	acc_descr VARCHAR(255),
 
-	PRIMARY KEY (acc_num, acc_type)
+	CONSTRAINT account_pkey PRIMARY KEY (acc_num, acc_type),
+	CONSTRAINT uq_account_descr UNIQUE (acc_type, acc_descr),
+	CONSTRAINT account_check CHECK (acc_num > 0)
 );
 
 CREATE TABLE sub_account (
@@ -56,16 +86,18 @@ CREATE TABLE sub_account (
 	ref_type  {{.MySQLInt}},
 	sub_descr TEXT,
 
-	FOREIGN KEY (ref_num, ref_type) REFERENCES account (acc_num, acc_type)
+	CONSTRAINT sub_account_ref_num_ref_type_fkey FOREIGN KEY (ref_num, ref_type) REFERENCES account (acc_num, acc_type)
 );
 CREATE INDEX idx_sub_account__m1 ON sub_account (ref_num, ref_type);
 
 CREATE TABLE catalog (
 	catalog_id  {{.MySQLInt}} PRIMARY KEY,
-	name        TEXT,
+	name        VARCHAR(60),
 	description TEXT,
 	price       FLOAT
 );
+CREATE INDEX idx_catalog_description ON catalog (description(100));
+CREATE INDEX idx_catalog_lower_name ON catalog ((lower(name)));
 
 CREATE TABLE magazine (
 	catalog_id {{.MySQLInt}} PRIMARY KEY REFERENCES catalog(catalog_id),
@@ -101,7 +133,7 @@ CREATE TABLE address (
 	address_id {{.MySQLInt}} PRIMARY KEY,
 	street     TEXT,
 	city       TEXT,
-	state      TEXT,
+	state      CHAR(2),
 	post_code  TEXT
 );
 
@@ -109,7 +141,7 @@ CREATE TABLE user_address (
 	user_id    {{.MySQLInt}} REFERENCES `user`(user_id),
 	address_id {{.MySQLInt}} REFERENCES address(address_id),
 
-	PRIMARY KEY (user_id, address_id)
+	CONSTRAINT user_address_pkey PRIMARY KEY (user_id, address_id)
 );
 
 INSERT INTO sex (id, name)
@@ -117,6 +149,16 @@ INSERT INTO sex (id, name)
 INSERT INTO sex (id, name)
 	VALUES(1, 'male');
 
+INSERT INTO status (id, name, label, sort_order, active)
+	VALUES(1, 'draft', 'Draft', 2, TRUE);
+INSERT INTO status (id, name, label, sort_order, active)
+	VALUES(2, 'published', 'Published', 1, TRUE);
+INSERT INTO status (id, name, label, sort_order, active)
+	VALUES(3, 'archived', 'Archived', 3, FALSE);
+
+INSERT INTO person (person_id, sex, mood)
+	VALUES(0, 1, 'happy');
+
 INSERT INTO types (int_, int8_, int16_, int32_, int64_, float32_, float64_, string_, binary_, byte_, rune_, bool_)
 	VALUES(0, 8, 16, 32, 64, 1.32, 1.64, 'one', '12', 65, 90, TRUE);
 
@@ -125,3 +167,6 @@ INSERT INTO times (typeId, datetime)
 INSERT INTO times (typeId, datetime)
 	VALUES(1, '0001-01-01T00:00:00Z');
 
+INSERT INTO note (note_id, body, tenant_id, created_by, updated_by)
+	VALUES(0, 'a', 1, 'foo', 'bar');
+
@@ -1,9 +1,12 @@
 // MACHINE GENERATED BY ModSQL (github.com/kless/modsql); DO NOT EDIT
 
 DROP TABLE sex CASCADE;
+DROP TABLE status CASCADE;
+DROP TABLE person CASCADE;
 DROP TABLE types CASCADE;
 DROP TABLE default_value CASCADE;
 DROP TABLE times CASCADE;
+DROP TABLE note CASCADE;
 DROP TABLE account CASCADE;
 DROP TABLE sub_account CASCADE;
 DROP TABLE catalog CASCADE;
@@ -14,4 +17,6 @@ DROP TABLE chapter CASCADE;
 DROP TABLE "user" CASCADE;
 DROP TABLE address CASCADE;
 DROP TABLE user_address CASCADE;
+DROP FUNCTION modsql_updated_at();
+DROP TYPE mood;
 
@@ -1,9 +1,27 @@
 // +build Postgres
 // MACHINE GENERATED BY ModSQL (github.com/kless/modsql); DO NOT EDIT
 
+CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');
+
+CREATE OR REPLACE FUNCTION modsql_updated_at() RETURNS trigger AS $$ BEGIN NEW.updated_at = (now() AT TIME ZONE 'UTC'); RETURN NEW; END; $$ LANGUAGE plpgsql;
+
 CREATE TABLE sex (
 	id   smallint PRIMARY KEY,
-	name text
+	name text UNIQUE
+);
+
+CREATE TABLE status (
+	id         smallint PRIMARY KEY,
+	name       text UNIQUE,
+	label      text,
+	sort_order {{.PostgresInt}},
+	active     boolean
+);
+
+CREATE TABLE person (
+	person_id {{.PostgresInt}} PRIMARY KEY,
+	sex       smallint REFERENCES sex(id),
+	mood      mood
 );
 
 CREATE TABLE types (
@@ -20,7 +38,8 @@ CREATE TABLE types (
 	rune_    integer,
 	bool_    boolean,
 
-	UNIQUE (float32_, float64_)
+	CONSTRAINT types_float32__float64__key UNIQUE (float32_, float64_),
+	CONSTRAINT types_byte__rune__key UNIQUE (byte_, rune_)
 );
 CREATE UNIQUE INDEX idx_types_float64_ ON types (float64_);
 CREATE INDEX idx_types_rune_ ON types (rune_);
@@ -30,8 +49,8 @@ CREATE TABLE default_value (
 	id       {{.PostgresInt}} PRIMARY KEY,
 	int8_    smallint DEFAULT 55,
 	float32_ real DEFAULT 10.2,
-	string_  text,
-	binary_  bytea,
+	string_  text DEFAULT 'it''s',
+	binary_  bytea DEFAULT '\x6162',
 	byte_    smallint DEFAULT 98,
 	rune_    integer DEFAULT 114,
 	bool_    boolean DEFAULT FALSE
@@ -39,15 +58,33 @@ CREATE TABLE default_value (
 
 CREATE TABLE times (
 	typeId   {{.PostgresInt}},
-	datetime timestamp without time zone
+	datetime timestamp without time zone DEFAULT (now() AT TIME ZONE 'UTC')
+);
+
+CREATE TABLE note (
+	note_id    {{.PostgresInt}} PRIMARY KEY,
+	body       text,
+	tenant_id  {{.PostgresInt}},
+	created_by text,
+	updated_by text,
+	created_at timestamp without time zone DEFAULT (now() AT TIME ZONE 'UTC'),
+	updated_at timestamp without time zone DEFAULT (now() AT TIME ZONE 'UTC'),
+	deleted_at timestamp without time zone
 );
+COMMENT ON TABLE note IS 'Notes attached to the tenant.';
+COMMENT ON COLUMN note.body IS 'Text written by the user, in Markdown; it''s not sanitized.';
+CREATE INDEX idx_note__m1 ON note (tenant_id);
+CREATE INDEX idx_note_recent ON note (tenant_id, created_at DESC);
+CREATE TRIGGER note_updated_at BEFORE UPDATE ON note FOR EACH ROW EXECUTE PROCEDURE modsql_updated_at();
 
 CREATE TABLE account (
 	acc_num   {{.PostgresInt}},
 	acc_type  {{.PostgresInt}},
 	acc_descr text,
 
-	PRIMARY KEY (acc_num, acc_type)
+	CONSTRAINT account_pkey PRIMARY KEY (acc_num, acc_type),
+	CONSTRAINT uq_account_descr UNIQUE (acc_type, acc_descr),
+	CONSTRAINT account_check CHECK (acc_num > 0)
 );
 
 CREATE TABLE sub_account (
@@ -56,16 +93,18 @@ CREATE TABLE sub_account (
 	ref_type  {{.PostgresInt}},
 	sub_descr text,
 
-	FOREIGN KEY (ref_num, ref_type) REFERENCES account (acc_num, acc_type)
+	CONSTRAINT sub_account_ref_num_ref_type_fkey FOREIGN KEY (ref_num, ref_type) REFERENCES account (acc_num, acc_type)
 );
 CREATE INDEX idx_sub_account__m1 ON sub_account (ref_num, ref_type);
 
 CREATE TABLE catalog (
 	catalog_id  {{.PostgresInt}} PRIMARY KEY,
-	name        text,
+	name        varchar(60),
 	description text,
 	price       real
 );
+CREATE INDEX idx_catalog_description ON catalog (description);
+CREATE INDEX idx_catalog_lower_name ON catalog ((lower(name)));
 
 CREATE TABLE magazine (
 	catalog_id {{.PostgresInt}} PRIMARY KEY REFERENCES catalog(catalog_id),
@@ -101,7 +140,7 @@ CREATE TABLE address (
 	address_id {{.PostgresInt}} PRIMARY KEY,
 	street     text,
 	city       text,
-	state      text,
+	state      char(2),
 	post_code  text
 );
 
@@ -109,7 +148,7 @@ CREATE TABLE user_address (
 	user_id    {{.PostgresInt}} REFERENCES "user"(user_id),
 	address_id {{.PostgresInt}} REFERENCES address(address_id),
 
-	PRIMARY KEY (user_id, address_id)
+	CONSTRAINT user_address_pkey PRIMARY KEY (user_id, address_id)
 );
 
 INSERT INTO sex (id, name)
@@ -117,6 +156,16 @@ INSERT INTO sex (id, name)
 INSERT INTO sex (id, name)
 	VALUES(1, 'male');
 
+INSERT INTO status (id, name, label, sort_order, active)
+	VALUES(1, 'draft', 'Draft', 2, TRUE);
+INSERT INTO status (id, name, label, sort_order, active)
+	VALUES(2, 'published', 'Published', 1, TRUE);
+INSERT INTO status (id, name, label, sort_order, active)
+	VALUES(3, 'archived', 'Archived', 3, FALSE);
+
+INSERT INTO person (person_id, sex, mood)
+	VALUES(0, 1, 'happy');
+
 INSERT INTO types (int_, int8_, int16_, int32_, int64_, float32_, float64_, string_, binary_, byte_, rune_, bool_)
 	VALUES(0, 8, 16, 32, 64, 1.32, 1.64, 'one', '12', 65, 90, TRUE);
 
@@ -125,3 +174,6 @@ INSERT INTO times (typeId, datetime)
 INSERT INTO times (typeId, datetime)
 	VALUES(1, '0001-01-01T00:00:00Z');
 
+INSERT INTO note (note_id, body, tenant_id, created_by, updated_by)
+	VALUES(0, 'a', 1, 'foo', 'bar');
+
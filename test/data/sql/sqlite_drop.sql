@@ -1,9 +1,12 @@
 // MACHINE GENERATED BY ModSQL (github.com/kless/modsql); DO NOT EDIT
 
 DROP TABLE sex;
+DROP TABLE status;
+DROP TABLE person;
 DROP TABLE types;
 DROP TABLE default_value;
 DROP TABLE times;
+DROP TABLE note;
 DROP TABLE account;
 DROP TABLE sub_account;
 DROP TABLE catalog;
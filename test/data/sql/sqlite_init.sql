@@ -3,7 +3,21 @@
 
 CREATE TABLE sex (
 	id   INTEGER PRIMARY KEY,
-	name TEXT
+	name TEXT UNIQUE
+);
+
+CREATE TABLE status (
+	id         INTEGER PRIMARY KEY,
+	name       TEXT UNIQUE,
+	label      TEXT,
+	sort_order INTEGER,
+	active     BOOL
+);
+
+CREATE TABLE person (
+	person_id INTEGER PRIMARY KEY,
+	sex       INTEGER REFERENCES sex(id),
+	mood      TEXT CHECK (mood IN ('sad', 'ok', 'happy'))
 );
 
 CREATE TABLE types (
@@ -20,7 +34,8 @@ CREATE TABLE types (
 	rune_    INTEGER,
 	bool_    BOOL,
 
-	UNIQUE (float32_, float64_)
+	CONSTRAINT types_float32__float64__key UNIQUE (float32_, float64_),
+	CONSTRAINT types_byte__rune__key UNIQUE (byte_, rune_)
 );
 CREATE UNIQUE INDEX idx_types_float64_ ON types (float64_);
 CREATE INDEX idx_types_rune_ ON types (rune_);
@@ -30,8 +45,8 @@ CREATE TABLE default_value (
 	id       INTEGER PRIMARY KEY,
 	int8_    INTEGER DEFAULT 55,
 	float32_ REAL DEFAULT 10.2,
-	string_  TEXT,
-	binary_  BLOB,
+	string_  TEXT DEFAULT 'it''s',
+	binary_  BLOB DEFAULT X'6162',
 	byte_    INTEGER DEFAULT 98,
 	rune_    INTEGER DEFAULT 114,
 	bool_    BOOL DEFAULT 0
@@ -39,15 +54,31 @@ CREATE TABLE default_value (
 
 CREATE TABLE times (
 	typeId   INTEGER,
-	datetime TIMESTAMP
+	datetime TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE note /* Notes attached to the tenant. */ (
+	note_id    INTEGER PRIMARY KEY,
+	body       TEXT /* Text written by the user, in Markdown; it's not sanitized. */,
+	tenant_id  INTEGER,
+	created_by TEXT,
+	updated_by TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP
+);
+CREATE INDEX idx_note__m1 ON note (tenant_id);
+CREATE INDEX idx_note_recent ON note (tenant_id, created_at DESC);
+CREATE TRIGGER note_updated_at AFTER UPDATE ON note FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE note SET updated_at = CURRENT_TIMESTAMP WHERE rowid = NEW.rowid; END;
+
 CREATE TABLE account (
 	acc_num   INTEGER,
 	acc_type  INTEGER,
 	acc_descr TEXT,
 
-	PRIMARY KEY (acc_num, acc_type)
+	CONSTRAINT account_pkey PRIMARY KEY (acc_num, acc_type),
+	CONSTRAINT uq_account_descr UNIQUE (acc_type, acc_descr),
+	CONSTRAINT account_check CHECK (acc_num > 0)
 );
 
 CREATE TABLE sub_account (
@@ -56,16 +87,18 @@ CREATE TABLE sub_account (
 	ref_type  INTEGER,
 	sub_descr TEXT,
 
-	FOREIGN KEY (ref_num, ref_type) REFERENCES account (acc_num, acc_type)
+	CONSTRAINT sub_account_ref_num_ref_type_fkey FOREIGN KEY (ref_num, ref_type) REFERENCES account (acc_num, acc_type)
 );
 CREATE INDEX idx_sub_account__m1 ON sub_account (ref_num, ref_type);
 
 CREATE TABLE catalog (
 	catalog_id  INTEGER PRIMARY KEY,
-	name        TEXT,
+	name        VARCHAR(60),
 	description TEXT,
 	price       REAL
 );
+CREATE INDEX idx_catalog_description ON catalog (description);
+CREATE INDEX idx_catalog_lower_name ON catalog ((lower(name)));
 
 CREATE TABLE magazine (
 	catalog_id INTEGER PRIMARY KEY REFERENCES catalog(catalog_id),
@@ -101,7 +134,7 @@ CREATE TABLE address (
 	address_id INTEGER PRIMARY KEY,
 	street     TEXT,
 	city       TEXT,
-	state      TEXT,
+	state      CHAR(2),
 	post_code  TEXT
 );
 
@@ -109,7 +142,7 @@ CREATE TABLE user_address (
 	user_id    INTEGER REFERENCES "user"(user_id),
 	address_id INTEGER REFERENCES address(address_id),
 
-	PRIMARY KEY (user_id, address_id)
+	CONSTRAINT user_address_pkey PRIMARY KEY (user_id, address_id)
 );
 
 INSERT INTO sex (id, name)
@@ -117,6 +150,16 @@ INSERT INTO sex (id, name)
 INSERT INTO sex (id, name)
 	VALUES(1, 'male');
 
+INSERT INTO status (id, name, label, sort_order, active)
+	VALUES(1, 'draft', 'Draft', 2, 1);
+INSERT INTO status (id, name, label, sort_order, active)
+	VALUES(2, 'published', 'Published', 1, 1);
+INSERT INTO status (id, name, label, sort_order, active)
+	VALUES(3, 'archived', 'Archived', 3, 0);
+
+INSERT INTO person (person_id, sex, mood)
+	VALUES(0, 1, 'happy');
+
 INSERT INTO types (int_, int8_, int16_, int32_, int64_, float32_, float64_, string_, binary_, byte_, rune_, bool_)
 	VALUES(0, 8, 16, 32, 64, 1.32, 1.64, 'one', '12', 65, 90, 1);
 
@@ -125,3 +168,6 @@ INSERT INTO times (typeId, datetime)
 INSERT INTO times (typeId, datetime)
 	VALUES(1, '0001-01-01T00:00:00Z');
 
+INSERT INTO note (note_id, body, tenant_id, created_by, updated_by)
+	VALUES(0, 'a', 1, 'foo', 'bar');
+
@@ -9,7 +9,10 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -22,7 +25,10 @@ import (
 
 // testInsert checks SQL statements generated from Go model.
 func testInsert(t *tasking.T, db *sql.DB, eng modsql.Engine) {
-	modsql.InitStatements(db, eng, model.Insert)
+	if err := modsql.InitStatements(db, eng, model.Insert, model.Upsert,
+		model.SelectByPK, model.Update, model.Delete, model.Exists, model.SelectRelated); err != nil {
+		t.Error(err)
+	}
 	defer func() {
 		if err := modsql.CloseStatements(); err != nil {
 			t.Error(err)
@@ -31,7 +37,7 @@ func testInsert(t *tasking.T, db *sql.DB, eng modsql.Engine) {
 
 	// insert inserts data without transaction
 	insert := func(model modsql.Modeler) {
-		if _, err := model.StmtInsert().Exec(model.Args()...); err != nil {
+		if _, err := model.Insert(context.Background(), db); err != nil {
 			t.Error(err)
 		}
 	}
@@ -40,10 +46,11 @@ func testInsert(t *tasking.T, db *sql.DB, eng modsql.Engine) {
 	nsec := regexp.MustCompilePOSIX(`\.[0-9]+ \+`)
 
 	// scan checks that output data is the same than input data.
+	// The columns to select are set in the query through the verb "%s".
 	scan := func(query string, input, output modsql.Modeler) {
-		rows := db.QueryRow(modsql.SQLReplacer(eng, query))
+		query = fmt.Sprintf(query, strings.Join(output.Columns(), ", "))
 
-		if err := rows.Scan(output.Args()...); err != nil {
+		if err := scanRow(db, modsql.SQLReplacer(eng, query), output); err != nil {
 			t.Errorf("query: %q\n%s", query, err)
 		} else {
 			in := fmt.Sprintf("%v", input)
@@ -64,104 +71,460 @@ func testInsert(t *tasking.T, db *sql.DB, eng modsql.Engine) {
 
 	inputTx := &model.Catalog{0, "a", "b", 1.32}
 
-	err := insertFromTx(db, inputTx)
+	err := insertFromTx(db, eng, inputTx)
 	if err != nil {
 		modsql.CloseStatements()
 		t.Error(err)
 	}
 
-	scan("SELECT * FROM catalog WHERE catalog_id = 0", inputTx, &model.Catalog{})
+	scan("SELECT %s FROM catalog WHERE catalog_id = 0", inputTx, &model.Catalog{})
 
 	// Check data input from SQL files
 
 	inputTypes := &model.Types{0, 8, 16, 32, 64, 1.32, 1.64, "one", []byte("12"), 'A', 'Z', true}
-	scan("SELECT * FROM types WHERE int_ = 0", inputTypes, &model.Types{})
+	scan("SELECT %s FROM types WHERE int_ = 0", inputTypes, &model.Types{})
+
+	inputPerson := &model.Person{0, model.SEX_MALE, model.MOOD_HAPPY}
+	scan("SELECT %s FROM person WHERE person_id = 0", inputPerson, &model.Person{})
+	testEnum(t)
+	testSyncEnums(t, db, eng)
+	testTimestamps(t, db)
+	testDefaults(t, db)
+	testValidate(t)
 
-	inputDef := &model.Default_value{0, 10, 10.10, "foo", []byte{'1', '2'}, 'a', 'z', false}
-	scan("SELECT * FROM default_value WHERE Id = 0", inputDef, &model.Default_value{})
+	inputDef := &model.DefaultValue{0, 10, 10.10, "foo", []byte{'1', '2'}, 'a', 'z', false}
+	scan("SELECT %s FROM default_value WHERE Id = 0", inputDef, &model.DefaultValue{})
 
 	inputTimes0 := &model.Times{0, time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)}
-	scan("SELECT * FROM times WHERE typeId = 0", inputTimes0, &model.Times{})
-	if inputTimes0.Datetime.IsZero() {
-		t.Error("inputTimes0.Datetime: should not be zero:", inputTimes0.Datetime)
+	scan("SELECT %s FROM times WHERE typeId = 0", inputTimes0, &model.Times{})
+	if inputTimes0.DateTime.IsZero() {
+		t.Error("inputTimes0.DateTime: should not be zero:", inputTimes0.DateTime)
 	}
 
 	inputTimes1 := &model.Times{1, time.Time{}}
-	scan("SELECT * FROM times WHERE typeId = 1", inputTimes1, &model.Times{})
-	if !inputTimes1.Datetime.IsZero() {
-		t.Error("inputTimes1.Datetime: should be zero:", inputTimes1.Datetime)
+	scan("SELECT %s FROM times WHERE typeId = 1", inputTimes1, &model.Times{})
+	if !inputTimes1.DateTime.IsZero() {
+		t.Error("inputTimes1.DateTime: should be zero:", inputTimes1.DateTime)
 	}
 
 	// Direct insert
 
 	input0 := &model.Types{1, 8, -16, -32, 64, -1.32, -1.64, "a", []byte{1, 2}, 8, 'r', true}
 	insert(input0)
-	scan("SELECT * FROM types WHERE int_ = 1", input0, &model.Types{})
+	scan("SELECT %s FROM types WHERE int_ = 1", input0, &model.Types{})
 
-	input1 := &model.Default_value{1, 8, 1.32, "a", []byte{1, 2}, 8, 'r', false}
+	input1 := &model.DefaultValue{1, 8, 1.32, "a", []byte{1, 2}, 8, 'r', false}
 	insert(input1)
-	scan("SELECT * FROM default_value WHERE id = 1", input1, &model.Default_value{})
+	scan("SELECT %s FROM default_value WHERE id = 1", input1, &model.DefaultValue{})
 
 	input2 := &model.Times{2, time.Now().UTC()}
 	insert(input2)
-	scan("SELECT * FROM times WHERE typeId = 2", input2, &model.Times{})
-	if input2.Datetime.IsZero() {
-		t.Error("input2.Datetime: should not be zero:", input2.Datetime)
+	scan("SELECT %s FROM times WHERE typeId = 2", input2, &model.Times{})
+	if input2.DateTime.IsZero() {
+		t.Error("input2.DateTime: should not be zero:", input2.DateTime)
 	}
 
 	input3 := &model.Account{11, 22, "a"}
 	insert(input3)
-	scan("SELECT * FROM account WHERE acc_num = 11", input3, &model.Account{})
+	scan("SELECT %s FROM account WHERE acc_num = 11", input3, &model.Account{})
 
-	input4 := &model.Sub_account{1, 11, 22, "a"}
+	input4 := &model.SubAccount{1, 11, 22, "a"}
 	insert(input4)
-	scan("SELECT * FROM sub_account WHERE sub_acc = 1", input4, &model.Sub_account{})
+	scan("SELECT %s FROM sub_account WHERE sub_acc = 1", input4, &model.SubAccount{})
 
 	input5 := &model.Catalog{33, "a", "b", 1.32}
 	insert(input5)
-	scan("SELECT * FROM catalog WHERE catalog_id = 33", input5, &model.Catalog{})
+	scan("SELECT %s FROM catalog WHERE catalog_id = 33", input5, &model.Catalog{})
 
 	input6 := &model.Magazine{33, "a"}
 	insert(input6)
-	scan("SELECT * FROM magazine WHERE catalog_id = 33", input6, &model.Magazine{})
+	scan("SELECT %s FROM magazine WHERE catalog_id = 33", input6, &model.Magazine{})
 
 	input7 := &model.Mp3{33, 1, 1.32, "a"}
 	insert(input7)
-	scan("SELECT * FROM mp3 WHERE catalog_id = 33", input7, &model.Mp3{})
+	scan("SELECT %s FROM mp3 WHERE catalog_id = 33", input7, &model.Mp3{})
 
 	input8 := &model.Book{44, "a", "b"}
 	insert(input8)
-	scan("SELECT * FROM book WHERE book_id = 44", input8, &model.Book{})
+	scan("SELECT %s FROM book WHERE book_id = 44", input8, &model.Book{})
+
+	testPK(t, db, input8)
+
+	batch := []*model.Book{{100, "a", "b"}, {101, "c", "d"}, {102, "e", "f"}}
+	if err = model.BatchInsertBook(context.Background(), db, nil, batch...); err != nil {
+		t.Error(err)
+	}
+	scan("SELECT %s FROM book WHERE book_id = 102", batch[2], &model.Book{})
+
+	upsert := &model.Book{102, "g", "h"}
+	if _, err = upsert.Upsert(context.Background(), db); err != nil {
+		t.Error(err)
+	}
+	scan("SELECT %s FROM book WHERE book_id = 102", upsert, &model.Book{})
 
 	input9 := &model.Chapter{1, "a", 44}
 	insert(input9)
-	scan("SELECT * FROM chapter WHERE chapter_id = 1", input9, &model.Chapter{})
+	scan("SELECT %s FROM chapter WHERE chapter_id = 1", input9, &model.Chapter{})
 
 	input10 := &model.User{55, "a", "b"}
 	insert(input10)
-	scan("SELECT * FROM {Q}user{Q} WHERE user_id = 55", input10, &model.User{})
+	scan("SELECT %s FROM {Q}user{Q} WHERE user_id = 55", input10, &model.User{})
 
 	input11 := &model.Address{66, "a", "b", "c", "d"}
 	insert(input11)
-	scan("SELECT * FROM address WHERE address_id = 66", input11, &model.Address{})
+	scan("SELECT %s FROM address WHERE address_id = 66", input11, &model.Address{})
 
-	input12 := &model.User_address{55, 66}
+	input12 := &model.UserAddress{55, 66}
 	insert(input12)
-	scan("SELECT * FROM user_address WHERE user_id = 55", input12, &model.User_address{})
+	scan("SELECT %s FROM user_address WHERE user_id = 55", input12, &model.UserAddress{})
+
+	testRelations(t, db, input9, input10)
+
+	if err = checkOrphans(t, db, eng); err != nil {
+		t.Error(err)
+	}
+	testOrphans(t, db, eng)
 }
 
-// insertFromTx inserts data through a transaction.
-func insertFromTx(db *sql.DB, model modsql.Modeler) error {
-	tx, err := db.Begin()
+// testOrphans checks that a row which references a value of enumeration which
+// does not exist is reported like orphan.
+func testOrphans(t *tasking.T, db *sql.DB, eng modsql.Engine) {
+	ctx := context.Background()
+	bad := &model.Person{99, model.Sex(99), model.MOOD_OK}
+
+	if eng == modsql.Postgres { // the foreign keys can not be disabled
+		if _, err := bad.Insert(ctx, db); !errors.Is(err, modsql.ErrForeignKeyViolation) {
+			t.Errorf("expected a foreign key violation, got %v", err)
+		}
+		return
+	}
+
+	conn, err := db.Conn(ctx)
 	if err != nil {
-		return err
+		t.Fatal(err)
 	}
+	defer conn.Close()
 
-	if _, err = tx.Stmt(model.StmtInsert()).Exec(model.Args()...); err != nil {
+	off, on := "SET FOREIGN_KEY_CHECKS = 0", "SET FOREIGN_KEY_CHECKS = 1"
+	if eng == modsql.SQLite {
+		off, on = "PRAGMA foreign_keys = OFF", "PRAGMA foreign_keys = ON"
+	}
+	if _, err = conn.ExecContext(ctx, off); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.ExecContext(ctx, on)
+
+	if _, err = bad.Insert(ctx, conn); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.ExecContext(ctx, "DELETE FROM person WHERE person_id = 99")
+
+	orphans, err := modsql.CheckOrphans(ctx, conn, eng, model.Relations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, v := range orphans {
+		if v.Table == "person" && v.RefTable == "sex" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("CheckOrphans: expected to report person.sex, got %v", orphans)
+	}
+}
+
+// scanRow scans the first row got from the query.
+func scanRow(db *sql.DB, query string, output modsql.Modeler) error {
+	rows, err := db.Query(query)
+	if err != nil {
 		return err
 	}
-	if err = tx.Commit(); err != nil {
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	return output.Scan(rows)
+}
+
+// insertFromTx inserts data through a transaction.
+func insertFromTx(db *sql.DB, eng modsql.Engine, model modsql.Modeler) error {
+	ctx := context.Background()
+
+	return modsql.RunInTx(ctx, db, eng, nil, func(tx *sql.Tx) error {
+		_, err := model.Insert(ctx, tx)
 		return err
+	})
+}
+
+// testPK checks the statements generated to handle a row by its primary key.
+func testPK(t *tasking.T, db *sql.DB, input *model.Book) {
+	ctx := context.Background()
+
+	output, err := model.GetBookByPK(ctx, db, input.BookID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *output != *input {
+		t.Errorf("got different data\ninput:  %v\noutput: %v\n", input, output)
+	}
+
+	output.Title = "c"
+	if _, err = output.Update(ctx, db); err != nil {
+		t.Error(err)
+	}
+	if output, err = model.GetBookByPK(ctx, db, input.BookID); err != nil {
+		t.Error(err)
+	} else if output.Title != "c" {
+		t.Errorf("got title %q after update, want %q", output.Title, "c")
+	}
+
+	tmp := &model.Book{45, "tmp", "tmp"}
+	if _, err = tmp.Insert(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = tmp.Delete(ctx, db); err != nil {
+		t.Error(err)
+	}
+	if found, err := tmp.Exists(ctx, db); err != nil {
+		t.Error(err)
+	} else if found {
+		t.Error("expected to have deleted the row")
+	}
+}
+
+// testRelations checks the methods generated to get the related rows.
+func testRelations(t *tasking.T, db *sql.DB, chapter *model.Chapter, user *model.User) {
+	ctx := context.Background()
+
+	book, err := chapter.Book(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.BookID != chapter.BookFk {
+		t.Errorf("Book: got book %d, want %d", book.BookID, chapter.BookFk)
+	}
+
+	if chapters, err := book.Chapters(ctx, db); err != nil {
+		t.Error(err)
+	} else if len(chapters) != 1 || *chapters[0] != *chapter {
+		t.Errorf("Chapters: got %v, want %v", chapters, chapter)
+	}
+
+	if books, err := model.LoadBooksForChapters(ctx, db, []*model.Chapter{chapter, chapter}); err != nil {
+		t.Error(err)
+	} else if len(books) != 1 || books[book.BookID] == nil {
+		t.Errorf("LoadBooksForChapters: got %v", books)
+	}
+
+	if addresses, err := user.Addresses(ctx, db); err != nil {
+		t.Error(err)
+	} else if len(addresses) != 1 || addresses[0].AddressID != 66 {
+		t.Errorf("Addresses: got %v", addresses)
+	}
+
+	if addresses, err := model.LoadAddressesForUsers(ctx, db, []*model.User{user}); err != nil {
+		t.Error(err)
+	} else if len(addresses[user.UserID]) != 1 {
+		t.Errorf("LoadAddressesForUsers: got %v", addresses)
+	}
+
+	// Cascade delete
+	if rows, err := book.DeleteCascade(ctx, db, true); err != nil {
+		t.Error(err)
+	} else if rows["chapter"] != 1 || rows["book"] != 1 {
+		t.Errorf("DeleteCascade: dry run: got %v", rows)
+	}
+	if _, err = book.DeleteCascade(ctx, db, false); err != nil {
+		t.Error(err)
+	}
+	if found, err := chapter.Exists(ctx, db); err != nil {
+		t.Error(err)
+	} else if found {
+		t.Error("DeleteCascade: expected to have deleted the chapter")
+	}
+}
+
+// testEnum checks the methods generated for the type of an enumeration.
+func testEnum(t *tasking.T) {
+	if sex, err := model.ParseSex("female"); err != nil || sex != model.SEX_FEMALE {
+		t.Errorf("ParseSex: got %v, %v", sex, err)
+	}
+	if _, err := model.ParseSex("foo"); err == nil {
+		t.Error("ParseSex: expected to get an error by unknown name")
+	}
+	if values := model.SEX_FEMALE.Values(); len(values) != 2 || values[1] != model.SEX_MALE {
+		t.Errorf("Values: got %v", values)
+	}
+
+	data, err := json.Marshal(&model.Person{1, model.SEX_FEMALE, model.MOOD_OK})
+	if err != nil {
+		t.Error(err)
+	} else if string(data) != `{"person_id":1,"sex":"female","mood":"ok"}` {
+		t.Errorf("MarshalText: got %s", data)
+	}
+
+	var sex model.Sex
+	if err = sex.Scan(int64(5)); err == nil {
+		t.Error("Scan: expected to get an error by value out of range")
+	}
+	if _, err = model.Sex(5).Value(); err == nil {
+		t.Error("Value: expected to get an error by value out of range")
+	}
+
+	// Extra attributes
+	if attr := model.STATUS_ARCHIVED.Attr(); attr.Label != "Archived" || attr.SortOrder != 3 || attr.Active {
+		t.Errorf("Attr: got %+v", attr)
+	}
+	if len(model.StatusAttrs) != 3 {
+		t.Errorf("StatusAttrs: got %d values, want 3", len(model.StatusAttrs))
+	}
+
+	// Native enumeration
+	var mood model.Mood
+	if err = mood.Scan([]byte("happy")); err != nil || mood != model.MOOD_HAPPY {
+		t.Errorf("Scan: got %v, %v", mood, err)
+	}
+	if err = mood.Scan("angry"); err == nil {
+		t.Error("Scan: expected to get an error by value out of the enumeration")
+	}
+	if _, err = json.Marshal(model.Mood("angry")); err == nil {
+		t.Error("MarshalText: expected to get an error by value out of the enumeration")
+	}
+}
+
+// testSyncEnums checks the synchronization of the tables of enumeration.
+func testSyncEnums(t *tasking.T, db *sql.DB, eng modsql.Engine) {
+	ctx := context.Background()
+
+	if reports, err := model.SyncEnums(ctx, db, true); err != nil {
+		t.Error(err)
+	} else if len(reports) != 0 {
+		t.Errorf("SyncEnums: expected no differences, got %v", reports)
+	}
+
+	if _, err := db.Exec("UPDATE sex SET name = 'foo' WHERE id = 1"); err != nil {
+		t.Fatal(err)
+	}
+	if reports, err := model.SyncEnums(ctx, db, false); err != nil {
+		t.Error(err)
+	} else if len(reports) != 1 || len(reports[0].Changed) != 1 {
+		t.Errorf("SyncEnums: got %v", reports)
+	}
+	if reports, err := model.SyncEnums(ctx, db, true); err != nil {
+		t.Error(err)
+	} else if len(reports) != 0 {
+		t.Errorf("SyncEnums: expected to have synchronized the enumerations, got %v", reports)
+	}
+
+	// The extra attributes are set at inserting the missing rows.
+	if _, err := db.Exec("DELETE FROM status WHERE id = 3"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := model.SyncEnums(ctx, db, false); err != nil {
+		t.Error(err)
+	}
+	var label string
+	if err := db.QueryRow("SELECT label FROM status WHERE id = 3").Scan(&label); err != nil {
+		t.Error(err)
+	} else if label != "Archived" {
+		t.Errorf("SyncEnums: got label %q, want %q", label, "Archived")
+	}
+
+	// The extra attributes which have changed are updated.
+	if _, err := db.Exec("UPDATE status SET label = 'foo' WHERE id = 1"); err != nil {
+		t.Fatal(err)
+	}
+	if reports, err := model.SyncEnums(ctx, db, false); err != nil {
+		t.Error(err)
+	} else if len(reports) != 1 || len(reports[0].Changed) != 1 {
+		t.Errorf("SyncEnums: expected a changed row, got %v", reports)
+	}
+	if err := db.QueryRow("SELECT label FROM status WHERE id = 1").Scan(&label); err != nil {
+		t.Error(err)
+	} else if label != model.STATUS_DRAFT.Attr().Label {
+		t.Errorf("SyncEnums: got label %q, want %q", label, model.STATUS_DRAFT.Attr().Label)
+	}
+}
+
+// testTimestamps checks the columns set by Timestamps and SoftDelete.
+func testTimestamps(t *tasking.T, db *sql.DB) {
+	ctx := context.Background()
+
+	note, err := model.GetNoteByPK(ctx, db, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if note.TenantID != 1 || note.Audit.CreatedBy != "foo" || note.Audit.UpdatedBy != "bar" {
+		t.Errorf("GetNoteByPK: got %+v", note)
+	}
+	if note.CreatedAt.IsZero() || note.UpdatedAt.IsZero() || note.DeletedAt.Valid {
+		t.Errorf("GetNoteByPK: expected to get the times set by the database, got %+v", note)
+	}
+
+	inserted := &model.Note{NoteID: 1, Body: "b"}
+	if _, err = inserted.Insert(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if note, err = model.GetNoteByPK(ctx, db, 1); err != nil {
+		t.Error(err)
+	} else if note.CreatedAt.IsZero() {
+		t.Error("Insert: expected to set the time of creation")
+	}
+
+	note.Body = "c"
+	if _, err = note.Update(ctx, db); err != nil {
+		t.Error(err)
+	}
+
+	// Soft delete
+	if _, err = note.Delete(ctx, db); err != nil {
+		t.Error(err)
+	}
+	if _, err = model.GetNoteByPK(ctx, db, 1); err != sql.ErrNoRows {
+		t.Errorf("GetNoteByPK: expected to skip the row marked like deleted, got %v", err)
+	}
+	if found, err := note.Exists(ctx, db); err != nil || found {
+		t.Errorf("Exists: got %v, %v", found, err)
+	}
+
+	var deleted int
+	if err = db.QueryRow("SELECT COUNT(*) FROM note WHERE note_id = 1 AND deleted_at IS NOT NULL").Scan(&deleted); err != nil {
+		t.Error(err)
+	} else if deleted != 1 {
+		t.Error("Delete: expected to mark the row like deleted")
+	}
+}
+
+// testDefaults checks the values by default of the columns.
+func testDefaults(t *tasking.T, db *sql.DB) {
+	if _, err := db.Exec("INSERT INTO default_value (id) VALUES (5)"); err != nil {
+		t.Fatal(err)
+	}
+
+	var s string
+	var b []byte
+	if err := db.QueryRow("SELECT string_, binary_ FROM default_value WHERE id = 5").Scan(&s, &b); err != nil {
+		t.Error(err)
+	} else if s != "it's" || string(b) != "ab" {
+		t.Errorf("got default values %q, %q", s, b)
+	}
+}
+
+// testValidate checks the length of the strings with a size.
+func testValidate(t *tasking.T) {
+	catalog := &model.Catalog{Name: strings.Repeat("ñ", 60)}
+	if err := catalog.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	catalog.Name += "a"
+	err := catalog.Validate()
+	if e, ok := err.(*modsql.LengthError); !ok || e.Column != "name" || e.Length != 61 {
+		t.Errorf("Validate: got error %v", err)
 	}
-	return nil
 }
@@ -3,7 +3,12 @@
 package model
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kless/modsql"
@@ -18,195 +23,2658 @@ const ENGINE = modsql.Postgres
 // * * *
 
 var Insert = modsql.NewStatements(map[int]string{
-	0:  "INSERT INTO types (int_, int8_, int16_, int32_, int64_, float32_, float64_, string_, binary_, byte_, rune_, bool_) VALUES({P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P})",
-	1:  "INSERT INTO default_value (id, int8_, float32_, string_, binary_, byte_, rune_, bool_) VALUES({P}, {P}, {P}, {P}, {P}, {P}, {P}, {P})",
-	2:  "INSERT INTO times (typeId, datetime) VALUES({P}, {P})",
-	3:  "INSERT INTO account (acc_num, acc_type, acc_descr) VALUES({P}, {P}, {P})",
-	4:  "INSERT INTO sub_account (sub_acc, ref_num, ref_type, sub_descr) VALUES({P}, {P}, {P}, {P})",
-	5:  "INSERT INTO catalog (catalog_id, name, description, price) VALUES({P}, {P}, {P}, {P})",
-	6:  "INSERT INTO magazine (catalog_id, page_count) VALUES({P}, {P})",
-	7:  "INSERT INTO mp3 (catalog_id, size, length, filename) VALUES({P}, {P}, {P}, {P})",
-	8:  "INSERT INTO book (book_id, title, author) VALUES({P}, {P}, {P})",
-	9:  "INSERT INTO chapter (chapter_id, title, book_fk) VALUES({P}, {P}, {P})",
-	10: "INSERT INTO {Q}user{Q} (user_id, first_name, last_name) VALUES({P}, {P}, {P})",
-	11: "INSERT INTO address (address_id, street, city, state, post_code) VALUES({P}, {P}, {P}, {P}, {P})",
-	12: "INSERT INTO user_address (user_id, address_id) VALUES({P}, {P})",
+	0:  "INSERT INTO person (person_id, sex, mood) VALUES({P}, {P}, {P})",
+	1:  "INSERT INTO types (int_, int8_, int16_, int32_, int64_, float32_, float64_, string_, binary_, byte_, rune_, bool_) VALUES({P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P})",
+	2:  "INSERT INTO default_value (id, int8_, float32_, string_, binary_, byte_, rune_, bool_) VALUES({P}, {P}, {P}, {P}, {P}, {P}, {P}, {P})",
+	3:  "INSERT INTO times (typeId, datetime) VALUES({P}, {P})",
+	4:  "INSERT INTO note (note_id, body, tenant_id, created_by, updated_by) VALUES({P}, {P}, {P}, {P}, {P})",
+	5:  "INSERT INTO account (acc_num, acc_type, acc_descr) VALUES({P}, {P}, {P})",
+	6:  "INSERT INTO sub_account (sub_acc, ref_num, ref_type, sub_descr) VALUES({P}, {P}, {P}, {P})",
+	7:  "INSERT INTO catalog (catalog_id, name, description, price) VALUES({P}, {P}, {P}, {P})",
+	8:  "INSERT INTO magazine (catalog_id, page_count) VALUES({P}, {P})",
+	9:  "INSERT INTO mp3 (catalog_id, size, length, filename) VALUES({P}, {P}, {P}, {P})",
+	10: "INSERT INTO book (book_id, title, author) VALUES({P}, {P}, {P})",
+	11: "INSERT INTO chapter (chapter_id, title, book_fk) VALUES({P}, {P}, {P})",
+	12: "INSERT INTO {Q}user{Q} (user_id, first_name, last_name) VALUES({P}, {P}, {P})",
+	13: "INSERT INTO address (address_id, street, city, state, post_code) VALUES({P}, {P}, {P}, {P}, {P})",
+	14: "INSERT INTO user_address (user_id, address_id) VALUES({P}, {P})",
 })
 
-// sex
+var SelectByPK = modsql.NewStatements(map[int]string{
+	0:  "SELECT person_id, sex, mood FROM person WHERE person_id = {P}",
+	1:  "SELECT int_, int8_, int16_, int32_, int64_, float32_, float64_, string_, binary_, byte_, rune_, bool_ FROM types WHERE int_ = {P}",
+	2:  "SELECT id, int8_, float32_, string_, binary_, byte_, rune_, bool_ FROM default_value WHERE id = {P}",
+	4:  "SELECT note_id, body, tenant_id, created_by, updated_by, created_at, updated_at, deleted_at FROM note WHERE note_id = {P} AND deleted_at IS NULL",
+	5:  "SELECT acc_num, acc_type, acc_descr FROM account WHERE acc_num = {P} AND acc_type = {P}",
+	6:  "SELECT sub_acc, ref_num, ref_type, sub_descr FROM sub_account WHERE sub_acc = {P}",
+	7:  "SELECT catalog_id, name, description, price FROM catalog WHERE catalog_id = {P}",
+	8:  "SELECT catalog_id, page_count FROM magazine WHERE catalog_id = {P}",
+	9:  "SELECT catalog_id, size, length, filename FROM mp3 WHERE catalog_id = {P}",
+	10: "SELECT book_id, title, author FROM book WHERE book_id = {P}",
+	11: "SELECT chapter_id, title, book_fk FROM chapter WHERE chapter_id = {P}",
+	12: "SELECT user_id, first_name, last_name FROM {Q}user{Q} WHERE user_id = {P}",
+	13: "SELECT address_id, street, city, state, post_code FROM address WHERE address_id = {P}",
+	14: "SELECT user_id, address_id FROM user_address WHERE user_id = {P} AND address_id = {P}",
+})
+
+var Update = modsql.NewStatements(map[int]string{
+	0:  "UPDATE person SET sex = {P}, mood = {P} WHERE person_id = {P}",
+	1:  "UPDATE types SET int8_ = {P}, int16_ = {P}, int32_ = {P}, int64_ = {P}, float32_ = {P}, float64_ = {P}, string_ = {P}, binary_ = {P}, byte_ = {P}, rune_ = {P}, bool_ = {P} WHERE int_ = {P}",
+	2:  "UPDATE default_value SET int8_ = {P}, float32_ = {P}, string_ = {P}, binary_ = {P}, byte_ = {P}, rune_ = {P}, bool_ = {P} WHERE id = {P}",
+	4:  "UPDATE note SET body = {P}, tenant_id = {P}, created_by = {P}, updated_by = {P}, updated_at = {NOW} WHERE note_id = {P} AND deleted_at IS NULL",
+	5:  "UPDATE account SET acc_descr = {P} WHERE acc_num = {P} AND acc_type = {P}",
+	6:  "UPDATE sub_account SET ref_num = {P}, ref_type = {P}, sub_descr = {P} WHERE sub_acc = {P}",
+	7:  "UPDATE catalog SET name = {P}, description = {P}, price = {P} WHERE catalog_id = {P}",
+	8:  "UPDATE magazine SET page_count = {P} WHERE catalog_id = {P}",
+	9:  "UPDATE mp3 SET size = {P}, length = {P}, filename = {P} WHERE catalog_id = {P}",
+	10: "UPDATE book SET title = {P}, author = {P} WHERE book_id = {P}",
+	11: "UPDATE chapter SET title = {P}, book_fk = {P} WHERE chapter_id = {P}",
+	12: "UPDATE {Q}user{Q} SET first_name = {P}, last_name = {P} WHERE user_id = {P}",
+	13: "UPDATE address SET street = {P}, city = {P}, state = {P}, post_code = {P} WHERE address_id = {P}",
+})
+
+var Delete = modsql.NewStatements(map[int]string{
+	0:  "DELETE FROM person WHERE person_id = {P}",
+	1:  "DELETE FROM types WHERE int_ = {P}",
+	2:  "DELETE FROM default_value WHERE id = {P}",
+	4:  "UPDATE note SET deleted_at = {NOW} WHERE note_id = {P} AND deleted_at IS NULL",
+	5:  "DELETE FROM account WHERE acc_num = {P} AND acc_type = {P}",
+	6:  "DELETE FROM sub_account WHERE sub_acc = {P}",
+	7:  "DELETE FROM catalog WHERE catalog_id = {P}",
+	8:  "DELETE FROM magazine WHERE catalog_id = {P}",
+	9:  "DELETE FROM mp3 WHERE catalog_id = {P}",
+	10: "DELETE FROM book WHERE book_id = {P}",
+	11: "DELETE FROM chapter WHERE chapter_id = {P}",
+	12: "DELETE FROM {Q}user{Q} WHERE user_id = {P}",
+	13: "DELETE FROM address WHERE address_id = {P}",
+	14: "DELETE FROM user_address WHERE user_id = {P} AND address_id = {P}",
+})
+
+var Exists = modsql.NewStatements(map[int]string{
+	0:  "SELECT 1 FROM person WHERE person_id = {P}",
+	1:  "SELECT 1 FROM types WHERE int_ = {P}",
+	2:  "SELECT 1 FROM default_value WHERE id = {P}",
+	4:  "SELECT 1 FROM note WHERE note_id = {P} AND deleted_at IS NULL",
+	5:  "SELECT 1 FROM account WHERE acc_num = {P} AND acc_type = {P}",
+	6:  "SELECT 1 FROM sub_account WHERE sub_acc = {P}",
+	7:  "SELECT 1 FROM catalog WHERE catalog_id = {P}",
+	8:  "SELECT 1 FROM magazine WHERE catalog_id = {P}",
+	9:  "SELECT 1 FROM mp3 WHERE catalog_id = {P}",
+	10: "SELECT 1 FROM book WHERE book_id = {P}",
+	11: "SELECT 1 FROM chapter WHERE chapter_id = {P}",
+	12: "SELECT 1 FROM {Q}user{Q} WHERE user_id = {P}",
+	13: "SELECT 1 FROM address WHERE address_id = {P}",
+	14: "SELECT 1 FROM user_address WHERE user_id = {P} AND address_id = {P}",
+})
+
+var Upsert = modsql.NewStatementsByEngine(map[modsql.Engine]map[int]string{
+	modsql.Postgres: {
+		0:  "INSERT INTO person (person_id, sex, mood) VALUES({P}, {P}, {P}) ON CONFLICT (person_id) DO UPDATE SET sex = excluded.sex, mood = excluded.mood",
+		1:  "INSERT INTO types (int_, int8_, int16_, int32_, int64_, float32_, float64_, string_, binary_, byte_, rune_, bool_) VALUES({P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}) ON CONFLICT (string_) DO UPDATE SET int8_ = excluded.int8_, int16_ = excluded.int16_",
+		2:  "INSERT INTO default_value (id, int8_, float32_, string_, binary_, byte_, rune_, bool_) VALUES({P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}) ON CONFLICT (id) DO UPDATE SET int8_ = excluded.int8_, float32_ = excluded.float32_, string_ = excluded.string_, binary_ = excluded.binary_, byte_ = excluded.byte_, rune_ = excluded.rune_, bool_ = excluded.bool_",
+		4:  "INSERT INTO note (note_id, body, tenant_id, created_by, updated_by) VALUES({P}, {P}, {P}, {P}, {P}) ON CONFLICT (note_id) DO UPDATE SET body = excluded.body, tenant_id = excluded.tenant_id, created_by = excluded.created_by, updated_by = excluded.updated_by, updated_at = {NOW}, deleted_at = NULL",
+		5:  "INSERT INTO account (acc_num, acc_type, acc_descr) VALUES({P}, {P}, {P}) ON CONFLICT (acc_num, acc_type) DO UPDATE SET acc_descr = excluded.acc_descr",
+		6:  "INSERT INTO sub_account (sub_acc, ref_num, ref_type, sub_descr) VALUES({P}, {P}, {P}, {P}) ON CONFLICT (sub_acc) DO UPDATE SET ref_num = excluded.ref_num, ref_type = excluded.ref_type, sub_descr = excluded.sub_descr",
+		7:  "INSERT INTO catalog (catalog_id, name, description, price) VALUES({P}, {P}, {P}, {P}) ON CONFLICT (catalog_id) DO UPDATE SET name = excluded.name, description = excluded.description, price = excluded.price",
+		8:  "INSERT INTO magazine (catalog_id, page_count) VALUES({P}, {P}) ON CONFLICT (catalog_id) DO UPDATE SET page_count = excluded.page_count",
+		9:  "INSERT INTO mp3 (catalog_id, size, length, filename) VALUES({P}, {P}, {P}, {P}) ON CONFLICT (catalog_id) DO UPDATE SET size = excluded.size, length = excluded.length, filename = excluded.filename",
+		10: "INSERT INTO book (book_id, title, author) VALUES({P}, {P}, {P}) ON CONFLICT (book_id) DO UPDATE SET title = excluded.title, author = excluded.author",
+		11: "INSERT INTO chapter (chapter_id, title, book_fk) VALUES({P}, {P}, {P}) ON CONFLICT (chapter_id) DO UPDATE SET title = excluded.title, book_fk = excluded.book_fk",
+		12: "INSERT INTO {Q}user{Q} (user_id, first_name, last_name) VALUES({P}, {P}, {P}) ON CONFLICT (user_id) DO UPDATE SET first_name = excluded.first_name, last_name = excluded.last_name",
+		13: "INSERT INTO address (address_id, street, city, state, post_code) VALUES({P}, {P}, {P}, {P}, {P}) ON CONFLICT (address_id) DO UPDATE SET street = excluded.street, city = excluded.city, state = excluded.state, post_code = excluded.post_code",
+		14: "INSERT INTO user_address (user_id, address_id) VALUES({P}, {P}) ON CONFLICT (user_id, address_id) DO NOTHING",
+	},
+	modsql.MySQL: {
+		0:  "INSERT INTO person (person_id, sex, mood) VALUES({P}, {P}, {P}) ON DUPLICATE KEY UPDATE sex = VALUES(sex), mood = VALUES(mood)",
+		1:  "INSERT INTO types (int_, int8_, int16_, int32_, int64_, float32_, float64_, string_, binary_, byte_, rune_, bool_) VALUES({P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}) ON DUPLICATE KEY UPDATE int8_ = VALUES(int8_), int16_ = VALUES(int16_)",
+		2:  "INSERT INTO default_value (id, int8_, float32_, string_, binary_, byte_, rune_, bool_) VALUES({P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}) ON DUPLICATE KEY UPDATE int8_ = VALUES(int8_), float32_ = VALUES(float32_), string_ = VALUES(string_), binary_ = VALUES(binary_), byte_ = VALUES(byte_), rune_ = VALUES(rune_), bool_ = VALUES(bool_)",
+		4:  "INSERT INTO note (note_id, body, tenant_id, created_by, updated_by) VALUES({P}, {P}, {P}, {P}, {P}) ON DUPLICATE KEY UPDATE body = VALUES(body), tenant_id = VALUES(tenant_id), created_by = VALUES(created_by), updated_by = VALUES(updated_by), updated_at = {NOW}, deleted_at = NULL",
+		5:  "INSERT INTO account (acc_num, acc_type, acc_descr) VALUES({P}, {P}, {P}) ON DUPLICATE KEY UPDATE acc_descr = VALUES(acc_descr)",
+		6:  "INSERT INTO sub_account (sub_acc, ref_num, ref_type, sub_descr) VALUES({P}, {P}, {P}, {P}) ON DUPLICATE KEY UPDATE ref_num = VALUES(ref_num), ref_type = VALUES(ref_type), sub_descr = VALUES(sub_descr)",
+		7:  "INSERT INTO catalog (catalog_id, name, description, price) VALUES({P}, {P}, {P}, {P}) ON DUPLICATE KEY UPDATE name = VALUES(name), description = VALUES(description), price = VALUES(price)",
+		8:  "INSERT INTO magazine (catalog_id, page_count) VALUES({P}, {P}) ON DUPLICATE KEY UPDATE page_count = VALUES(page_count)",
+		9:  "INSERT INTO mp3 (catalog_id, size, length, filename) VALUES({P}, {P}, {P}, {P}) ON DUPLICATE KEY UPDATE size = VALUES(size), length = VALUES(length), filename = VALUES(filename)",
+		10: "INSERT INTO book (book_id, title, author) VALUES({P}, {P}, {P}) ON DUPLICATE KEY UPDATE title = VALUES(title), author = VALUES(author)",
+		11: "INSERT INTO chapter (chapter_id, title, book_fk) VALUES({P}, {P}, {P}) ON DUPLICATE KEY UPDATE title = VALUES(title), book_fk = VALUES(book_fk)",
+		12: "INSERT INTO {Q}user{Q} (user_id, first_name, last_name) VALUES({P}, {P}, {P}) ON DUPLICATE KEY UPDATE first_name = VALUES(first_name), last_name = VALUES(last_name)",
+		13: "INSERT INTO address (address_id, street, city, state, post_code) VALUES({P}, {P}, {P}, {P}, {P}) ON DUPLICATE KEY UPDATE street = VALUES(street), city = VALUES(city), state = VALUES(state), post_code = VALUES(post_code)",
+		14: "INSERT INTO user_address (user_id, address_id) VALUES({P}, {P}) ON DUPLICATE KEY UPDATE user_id = user_id",
+	},
+	modsql.SQLite: {
+		0:  "INSERT INTO person (person_id, sex, mood) VALUES({P}, {P}, {P}) ON CONFLICT (person_id) DO UPDATE SET sex = excluded.sex, mood = excluded.mood",
+		1:  "INSERT INTO types (int_, int8_, int16_, int32_, int64_, float32_, float64_, string_, binary_, byte_, rune_, bool_) VALUES({P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}) ON CONFLICT (string_) DO UPDATE SET int8_ = excluded.int8_, int16_ = excluded.int16_",
+		2:  "INSERT INTO default_value (id, int8_, float32_, string_, binary_, byte_, rune_, bool_) VALUES({P}, {P}, {P}, {P}, {P}, {P}, {P}, {P}) ON CONFLICT (id) DO UPDATE SET int8_ = excluded.int8_, float32_ = excluded.float32_, string_ = excluded.string_, binary_ = excluded.binary_, byte_ = excluded.byte_, rune_ = excluded.rune_, bool_ = excluded.bool_",
+		4:  "INSERT INTO note (note_id, body, tenant_id, created_by, updated_by) VALUES({P}, {P}, {P}, {P}, {P}) ON CONFLICT (note_id) DO UPDATE SET body = excluded.body, tenant_id = excluded.tenant_id, created_by = excluded.created_by, updated_by = excluded.updated_by, updated_at = {NOW}, deleted_at = NULL",
+		5:  "INSERT INTO account (acc_num, acc_type, acc_descr) VALUES({P}, {P}, {P}) ON CONFLICT (acc_num, acc_type) DO UPDATE SET acc_descr = excluded.acc_descr",
+		6:  "INSERT INTO sub_account (sub_acc, ref_num, ref_type, sub_descr) VALUES({P}, {P}, {P}, {P}) ON CONFLICT (sub_acc) DO UPDATE SET ref_num = excluded.ref_num, ref_type = excluded.ref_type, sub_descr = excluded.sub_descr",
+		7:  "INSERT INTO catalog (catalog_id, name, description, price) VALUES({P}, {P}, {P}, {P}) ON CONFLICT (catalog_id) DO UPDATE SET name = excluded.name, description = excluded.description, price = excluded.price",
+		8:  "INSERT INTO magazine (catalog_id, page_count) VALUES({P}, {P}) ON CONFLICT (catalog_id) DO UPDATE SET page_count = excluded.page_count",
+		9:  "INSERT INTO mp3 (catalog_id, size, length, filename) VALUES({P}, {P}, {P}, {P}) ON CONFLICT (catalog_id) DO UPDATE SET size = excluded.size, length = excluded.length, filename = excluded.filename",
+		10: "INSERT INTO book (book_id, title, author) VALUES({P}, {P}, {P}) ON CONFLICT (book_id) DO UPDATE SET title = excluded.title, author = excluded.author",
+		11: "INSERT INTO chapter (chapter_id, title, book_fk) VALUES({P}, {P}, {P}) ON CONFLICT (chapter_id) DO UPDATE SET title = excluded.title, book_fk = excluded.book_fk",
+		12: "INSERT INTO {Q}user{Q} (user_id, first_name, last_name) VALUES({P}, {P}, {P}) ON CONFLICT (user_id) DO UPDATE SET first_name = excluded.first_name, last_name = excluded.last_name",
+		13: "INSERT INTO address (address_id, street, city, state, post_code) VALUES({P}, {P}, {P}, {P}, {P}) ON CONFLICT (address_id) DO UPDATE SET street = excluded.street, city = excluded.city, state = excluded.state, post_code = excluded.post_code",
+		14: "INSERT INTO user_address (user_id, address_id) VALUES({P}, {P}) ON CONFLICT (user_id, address_id) DO NOTHING",
+	},
+})
+
+var SelectRelated = modsql.NewStatements(map[int]string{
+	0:  "SELECT acc_num, acc_type, acc_descr FROM account WHERE acc_num = {P} AND acc_type = {P}",
+	1:  "SELECT sub_acc, ref_num, ref_type, sub_descr FROM sub_account WHERE ref_num = {P} AND ref_type = {P}",
+	2:  "SELECT catalog_id, name, description, price FROM catalog WHERE catalog_id = {P}",
+	3:  "SELECT catalog_id, page_count FROM magazine WHERE catalog_id = {P}",
+	4:  "SELECT catalog_id, name, description, price FROM catalog WHERE catalog_id = {P}",
+	5:  "SELECT catalog_id, size, length, filename FROM mp3 WHERE catalog_id = {P}",
+	6:  "SELECT book_id, title, author FROM book WHERE book_id = {P}",
+	7:  "SELECT chapter_id, title, book_fk FROM chapter WHERE book_fk = {P}",
+	8:  "SELECT user_id, first_name, last_name FROM {Q}user{Q} WHERE user_id = {P}",
+	9:  "SELECT user_id, address_id FROM user_address WHERE user_id = {P}",
+	10: "SELECT address_id, street, city, state, post_code FROM address WHERE address_id = {P}",
+	11: "SELECT user_id, address_id FROM user_address WHERE address_id = {P}",
+	12: "SELECT address.address_id, address.street, address.city, address.state, address.post_code FROM address JOIN user_address ON user_address.address_id = address.address_id WHERE user_address.user_id = {P}",
+	13: "SELECT {Q}user{Q}.user_id, {Q}user{Q}.first_name, {Q}user{Q}.last_name FROM {Q}user{Q} JOIN user_address ON user_address.user_id = {Q}user{Q}.user_id WHERE user_address.address_id = {P}",
+})
+
+func init() {
+	modsql.RegisterConstraints(modsql.Postgres,
+		modsql.Constraint{Name: "sex_pkey", Kind: modsql.ErrUniqueViolation, Table: "sex", Columns: []string{"id"}},
+		modsql.Constraint{Name: "sex_name_key", Kind: modsql.ErrUniqueViolation, Table: "sex", Columns: []string{"name"}},
+		modsql.Constraint{Name: "status_pkey", Kind: modsql.ErrUniqueViolation, Table: "status", Columns: []string{"id"}},
+		modsql.Constraint{Name: "status_name_key", Kind: modsql.ErrUniqueViolation, Table: "status", Columns: []string{"name"}},
+		modsql.Constraint{Name: "person_pkey", Kind: modsql.ErrUniqueViolation, Table: "person", Columns: []string{"person_id"}},
+		modsql.Constraint{Name: "person_sex_fkey", Kind: modsql.ErrForeignKeyViolation, Table: "person", Columns: []string{"sex"}},
+		modsql.Constraint{Name: "types_float32__float64__key", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"float32_", "float64_"}},
+		modsql.Constraint{Name: "types_byte__rune__key", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"byte_", "rune_"}},
+		modsql.Constraint{Name: "types_pkey", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"int_"}},
+		modsql.Constraint{Name: "types_string__key", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"string_"}},
+		modsql.Constraint{Name: "idx_types_float64_", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"float64_"}},
+		modsql.Constraint{Name: "idx_types__m1", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"int16_", "int32_"}},
+		modsql.Constraint{Name: "default_value_pkey", Kind: modsql.ErrUniqueViolation, Table: "default_value", Columns: []string{"id"}},
+		modsql.Constraint{Name: "note_pkey", Kind: modsql.ErrUniqueViolation, Table: "note", Columns: []string{"note_id"}},
+		modsql.Constraint{Name: "account_pkey", Kind: modsql.ErrUniqueViolation, Table: "account", Columns: []string{"acc_num", "acc_type"}},
+		modsql.Constraint{Name: "uq_account_descr", Kind: modsql.ErrUniqueViolation, Table: "account", Columns: []string{"acc_type", "acc_descr"}},
+		modsql.Constraint{Name: "account_check", Kind: modsql.ErrCheckViolation, Table: "account"},
+		modsql.Constraint{Name: "sub_account_ref_num_ref_type_fkey", Kind: modsql.ErrForeignKeyViolation, Table: "sub_account", Columns: []string{"ref_num", "ref_type"}},
+		modsql.Constraint{Name: "sub_account_pkey", Kind: modsql.ErrUniqueViolation, Table: "sub_account", Columns: []string{"sub_acc"}},
+		modsql.Constraint{Name: "catalog_pkey", Kind: modsql.ErrUniqueViolation, Table: "catalog", Columns: []string{"catalog_id"}},
+		modsql.Constraint{Name: "magazine_pkey", Kind: modsql.ErrUniqueViolation, Table: "magazine", Columns: []string{"catalog_id"}},
+		modsql.Constraint{Name: "magazine_catalog_id_fkey", Kind: modsql.ErrForeignKeyViolation, Table: "magazine", Columns: []string{"catalog_id"}},
+		modsql.Constraint{Name: "mp3_pkey", Kind: modsql.ErrUniqueViolation, Table: "mp3", Columns: []string{"catalog_id"}},
+		modsql.Constraint{Name: "mp3_catalog_id_fkey", Kind: modsql.ErrForeignKeyViolation, Table: "mp3", Columns: []string{"catalog_id"}},
+		modsql.Constraint{Name: "book_pkey", Kind: modsql.ErrUniqueViolation, Table: "book", Columns: []string{"book_id"}},
+		modsql.Constraint{Name: "chapter_pkey", Kind: modsql.ErrUniqueViolation, Table: "chapter", Columns: []string{"chapter_id"}},
+		modsql.Constraint{Name: "chapter_book_fk_fkey", Kind: modsql.ErrForeignKeyViolation, Table: "chapter", Columns: []string{"book_fk"}},
+		modsql.Constraint{Name: "user_pkey", Kind: modsql.ErrUniqueViolation, Table: "user", Columns: []string{"user_id"}},
+		modsql.Constraint{Name: "address_pkey", Kind: modsql.ErrUniqueViolation, Table: "address", Columns: []string{"address_id"}},
+		modsql.Constraint{Name: "user_address_pkey", Kind: modsql.ErrUniqueViolation, Table: "user_address", Columns: []string{"user_id", "address_id"}},
+		modsql.Constraint{Name: "user_address_user_id_fkey", Kind: modsql.ErrForeignKeyViolation, Table: "user_address", Columns: []string{"user_id"}},
+		modsql.Constraint{Name: "user_address_address_id_fkey", Kind: modsql.ErrForeignKeyViolation, Table: "user_address", Columns: []string{"address_id"}},
+	)
+	modsql.RegisterConstraints(modsql.MySQL,
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "sex", Columns: []string{"id"}},
+		modsql.Constraint{Name: "name", Kind: modsql.ErrUniqueViolation, Table: "sex", Columns: []string{"name"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "status", Columns: []string{"id"}},
+		modsql.Constraint{Name: "name", Kind: modsql.ErrUniqueViolation, Table: "status", Columns: []string{"name"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "person", Columns: []string{"person_id"}},
+		modsql.Constraint{Name: "types_float32__float64__key", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"float32_", "float64_"}},
+		modsql.Constraint{Name: "types_byte__rune__key", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"byte_", "rune_"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"int_"}},
+		modsql.Constraint{Name: "string_", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"string_"}},
+		modsql.Constraint{Name: "idx_types_float64_", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"float64_"}},
+		modsql.Constraint{Name: "idx_types__m1", Kind: modsql.ErrUniqueViolation, Table: "types", Columns: []string{"int16_", "int32_"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "default_value", Columns: []string{"id"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "note", Columns: []string{"note_id"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "account", Columns: []string{"acc_num", "acc_type"}},
+		modsql.Constraint{Name: "uq_account_descr", Kind: modsql.ErrUniqueViolation, Table: "account", Columns: []string{"acc_type", "acc_descr"}},
+		modsql.Constraint{Name: "account_check", Kind: modsql.ErrCheckViolation, Table: "account"},
+		modsql.Constraint{Name: "sub_account_ref_num_ref_type_fkey", Kind: modsql.ErrForeignKeyViolation, Table: "sub_account", Columns: []string{"ref_num", "ref_type"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "sub_account", Columns: []string{"sub_acc"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "catalog", Columns: []string{"catalog_id"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "magazine", Columns: []string{"catalog_id"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "mp3", Columns: []string{"catalog_id"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "book", Columns: []string{"book_id"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "chapter", Columns: []string{"chapter_id"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "user", Columns: []string{"user_id"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "address", Columns: []string{"address_id"}},
+		modsql.Constraint{Name: "PRIMARY", Kind: modsql.ErrUniqueViolation, Table: "user_address", Columns: []string{"user_id", "address_id"}},
+	)
+	modsql.RegisterConstraints(modsql.SQLite,
+		modsql.Constraint{Name: "account_check", Kind: modsql.ErrCheckViolation, Table: "account"},
+	)
+}
+
+// Mood is the native enumeration "mood".
+type Mood string
+
 const (
-	SEX_FEMALE = iota
+	MOOD_SAD   Mood = "sad"
+	MOOD_OK    Mood = "ok"
+	MOOD_HAPPY Mood = "happy"
+)
+
+// IsValid reports whether the value is in the enumeration.
+func (e Mood) IsValid() bool {
+	switch e {
+	case MOOD_SAD, MOOD_OK, MOOD_HAPPY:
+		return true
+	}
+	return false
+}
+
+// Values returns all values of the enumeration.
+func (Mood) Values() []Mood {
+	return []Mood{MOOD_SAD, MOOD_OK, MOOD_HAPPY}
+}
+
+// ParseMood returns the value of the enumeration with the given name.
+func ParseMood(s string) (Mood, error) {
+	if e := Mood(s); e.IsValid() {
+		return e, nil
+	}
+	return "", fmt.Errorf("invalid name for Mood: %q", s)
+}
+
+func (e Mood) MarshalText() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid value for Mood: %q", string(e))
+	}
+	return []byte(e), nil
+}
+
+func (e *Mood) UnmarshalText(text []byte) error {
+	v, err := ParseMood(string(text))
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (e *Mood) Scan(src interface{}) error {
+	var s string
+
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("scan Mood: unsupported type %T", src)
+	}
+
+	v, err := ParseMood(s)
+	if err != nil {
+		return fmt.Errorf("scan Mood: %s", err)
+	}
+	*e = v
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (e Mood) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid value for Mood: %q", string(e))
+	}
+	return string(e), nil
+}
+
+// Sex is the enumeration of the table "sex".
+type Sex int8
+
+const (
+	SEX_FEMALE Sex = iota
 	SEX_MALE
 )
 
+var sexNames = []string{"female", "male"}
+
+// String returns the name of the value in the enumeration.
+func (e Sex) String() string {
+	if e.IsValid() {
+		return sexNames[int(e)-0]
+	}
+	return "Sex(" + strconv.FormatInt(int64(e), 10) + ")"
+}
+
+// IsValid reports whether the value is in the enumeration.
+func (e Sex) IsValid() bool {
+	return int(e) >= 0 && int(e)-0 < len(sexNames)
+}
+
+// Values returns all values of the enumeration.
+func (Sex) Values() []Sex {
+	values := make([]Sex, len(sexNames))
+	for i := range values {
+		values[i] = Sex(i + 0)
+	}
+	return values
+}
+
+// ParseSex returns the value of the enumeration with the given name.
+func ParseSex(s string) (Sex, error) {
+	for i, v := range sexNames {
+		if v == s {
+			return Sex(i + 0), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid name for Sex: %q", s)
+}
+
+func (e Sex) MarshalText() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid value for Sex: %d", e)
+	}
+	return []byte(e.String()), nil
+}
+
+func (e *Sex) UnmarshalText(text []byte) error {
+	v, err := ParseSex(string(text))
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (e *Sex) Scan(src interface{}) error {
+	var n int64
+	var err error
+
+	switch v := src.(type) {
+	case int64:
+		n = v
+	case []byte:
+		n, err = strconv.ParseInt(string(v), 10, 64)
+	case string:
+		n, err = strconv.ParseInt(v, 10, 64)
+	default:
+		err = fmt.Errorf("unsupported type %T", src)
+	}
+	if err != nil {
+		return fmt.Errorf("scan Sex: %s", err)
+	}
+
+	if v := Sex(n); int64(v) == n && v.IsValid() {
+		*e = v
+		return nil
+	}
+	return fmt.Errorf("scan Sex: invalid value %d", n)
+}
+
+// Value implements the driver.Valuer interface.
+func (e Sex) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid value for Sex: %d", e)
+	}
+	return int64(e), nil
+}
+
+// Status is the enumeration of the table "status".
+type Status int8
+
+const (
+	STATUS_DRAFT Status = iota + 1
+	STATUS_PUBLISHED
+	STATUS_ARCHIVED
+)
+
+var statusNames = []string{"draft", "published", "archived"}
+
+// String returns the name of the value in the enumeration.
+func (e Status) String() string {
+	if e.IsValid() {
+		return statusNames[int(e)-1]
+	}
+	return "Status(" + strconv.FormatInt(int64(e), 10) + ")"
+}
+
+// IsValid reports whether the value is in the enumeration.
+func (e Status) IsValid() bool {
+	return int(e) >= 1 && int(e)-1 < len(statusNames)
+}
+
+// Values returns all values of the enumeration.
+func (Status) Values() []Status {
+	values := make([]Status, len(statusNames))
+	for i := range values {
+		values[i] = Status(i + 1)
+	}
+	return values
+}
+
+// ParseStatus returns the value of the enumeration with the given name.
+func ParseStatus(s string) (Status, error) {
+	for i, v := range statusNames {
+		if v == s {
+			return Status(i + 1), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid name for Status: %q", s)
+}
+
+func (e Status) MarshalText() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid value for Status: %d", e)
+	}
+	return []byte(e.String()), nil
+}
+
+func (e *Status) UnmarshalText(text []byte) error {
+	v, err := ParseStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (e *Status) Scan(src interface{}) error {
+	var n int64
+	var err error
+
+	switch v := src.(type) {
+	case int64:
+		n = v
+	case []byte:
+		n, err = strconv.ParseInt(string(v), 10, 64)
+	case string:
+		n, err = strconv.ParseInt(v, 10, 64)
+	default:
+		err = fmt.Errorf("unsupported type %T", src)
+	}
+	if err != nil {
+		return fmt.Errorf("scan Status: %s", err)
+	}
+
+	if v := Status(n); int64(v) == n && v.IsValid() {
+		*e = v
+		return nil
+	}
+	return fmt.Errorf("scan Status: invalid value %d", n)
+}
+
+// Value implements the driver.Valuer interface.
+func (e Status) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid value for Status: %d", e)
+	}
+	return int64(e), nil
+}
+
+// StatusAttr has the extra attributes of a value of Status.
+type StatusAttr struct {
+	Label     string `db:"label" json:"label"`
+	SortOrder int    `db:"sort_order" json:"sort_order"`
+	Active    bool   `db:"active" json:"active"`
+}
+
+// StatusAttrs has the extra attributes of every value of Status.
+var StatusAttrs = map[Status]StatusAttr{
+	STATUS_DRAFT:     {Label: "Draft", SortOrder: 2, Active: true},
+	STATUS_PUBLISHED: {Label: "Published", SortOrder: 1, Active: true},
+	STATUS_ARCHIVED:  {Label: "Archived", SortOrder: 3, Active: false},
+}
+
+// Attr returns the extra attributes of the value.
+func (e Status) Attr() StatusAttr {
+	return StatusAttrs[e]
+}
+
+type Person struct {
+	PersonID int  `db:"person_id" json:"person_id"`
+	Sex      Sex  `db:"sex" json:"sex"`
+	Mood     Mood `db:"mood" json:"mood"`
+}
+
+func (t *Person) Args() []interface{} {
+	return []interface{}{&t.PersonID, &t.Sex, &t.Mood}
+}
+
+func (t *Person) StmtInsert() (*sql.Stmt, error) { return Insert.Get(0) }
+
+func (t *Person) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 0, t.Args()...)
+}
+
+// BatchInsertPerson inserts several rows within a transaction.
+func BatchInsertPerson(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*Person) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "person", new(Person).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (person_id) already exists.
+func (t *Person) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(0) }
+
+// Upsert inserts the data, or updates it if the key (person_id) already exists.
+func (t *Person) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 0, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *Person) Columns() []string {
+	return []string{"person_id", "sex", "mood"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *Person) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "person_id":
+			dest[i] = &t.PersonID
+		case "sex":
+			dest[i] = &t.Sex
+		case "mood":
+			dest[i] = &t.Mood
+		default:
+			v, err := modsql.UnknownColumn("person", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *Person) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetPersonByPK returns the row of person with the given primary key.
+func GetPersonByPK(ctx context.Context, q modsql.Querier, personID int) (*Person, error) {
+	t := new(Person)
+	err := SelectByPK.QueryRowContext(ctx, q, 0, personID).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Person) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 0, &t.PersonID)
+}
+
+func (t *Person) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 0, &t.PersonID).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *Person) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 0, &t.Sex, &t.Mood, &t.PersonID)
+}
+
 type Types struct {
-	Int_     int
-	Int8_    int8
-	Int16_   int16
-	Int32_   int32
-	Int64_   int64
-	Float32_ float32
-	Float64_ float64
-	String_  string
-	Binary_  []byte
-	Byte_    byte
-	Rune_    rune
-	Bool_    bool
+	Int     int     `db:"int_" json:"int_"`
+	Int8    int8    `db:"int8_" json:"int8_"`
+	Int16   int16   `db:"int16_" json:"int16_"`
+	Int32   int32   `db:"int32_" json:"int32_"`
+	Int64   int64   `db:"int64_" json:"int64_"`
+	Float32 float32 `db:"float32_" json:"float32_"`
+	Float64 float64 `db:"float64_" json:"float64_"`
+	String  string  `db:"string_" json:"string_"`
+	Binary  []byte  `db:"binary_" json:"binary_"`
+	Byte    byte    `db:"byte_" json:"byte_"`
+	Rune    rune    `db:"rune_" json:"rune_"`
+	Bool    bool    `db:"bool_" json:"bool_"`
 }
 
 func (t *Types) Args() []interface{} {
-	return []interface{}{&t.Int_, &t.Int8_, &t.Int16_, &t.Int32_, &t.Int64_, &t.Float32_, &t.Float64_, &t.String_, &t.Binary_, &t.Byte_, &t.Rune_, &t.Bool_}
+	return []interface{}{&t.Int, &t.Int8, &t.Int16, &t.Int32, &t.Int64, &t.Float32, &t.Float64, &t.String, &t.Binary, &t.Byte, &t.Rune, &t.Bool}
+}
+
+func (t *Types) StmtInsert() (*sql.Stmt, error) { return Insert.Get(1) }
+
+func (t *Types) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 1, t.Args()...)
+}
+
+// BatchInsertTypes inserts several rows within a transaction.
+func BatchInsertTypes(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*Types) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "types", new(Types).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (string_) already exists.
+//
+// In MySQL, it is also updated on a conflict with the other unique keys (int_; float64_; float32_, float64_; byte_, rune_; int16_, int32_).
+func (t *Types) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(1) }
+
+// Upsert inserts the data, or updates it if the key (string_) already exists.
+//
+// In MySQL, it is also updated on a conflict with the other unique keys (int_; float64_; float32_, float64_; byte_, rune_; int16_, int32_).
+func (t *Types) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 1, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *Types) Columns() []string {
+	return []string{"int_", "int8_", "int16_", "int32_", "int64_", "float32_", "float64_", "string_", "binary_", "byte_", "rune_", "bool_"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *Types) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "int_":
+			dest[i] = &t.Int
+		case "int8_":
+			dest[i] = &t.Int8
+		case "int16_":
+			dest[i] = &t.Int16
+		case "int32_":
+			dest[i] = &t.Int32
+		case "int64_":
+			dest[i] = &t.Int64
+		case "float32_":
+			dest[i] = &t.Float32
+		case "float64_":
+			dest[i] = &t.Float64
+		case "string_":
+			dest[i] = &t.String
+		case "binary_":
+			dest[i] = &t.Binary
+		case "byte_":
+			dest[i] = &t.Byte
+		case "rune_":
+			dest[i] = &t.Rune
+		case "bool_":
+			dest[i] = &t.Bool
+		default:
+			v, err := modsql.UnknownColumn("types", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *Types) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetTypesByPK returns the row of types with the given primary key.
+func GetTypesByPK(ctx context.Context, q modsql.Querier, int_ int) (*Types, error) {
+	t := new(Types)
+	err := SelectByPK.QueryRowContext(ctx, q, 1, int_).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Types) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 1, &t.Int)
+}
+
+func (t *Types) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 1, &t.Int).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *Types) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 1, &t.Int8, &t.Int16, &t.Int32, &t.Int64, &t.Float32, &t.Float64, &t.String, &t.Binary, &t.Byte, &t.Rune, &t.Bool, &t.Int)
+}
+
+type DefaultValue struct {
+	ID      int     `db:"id" json:"id"`
+	Int8    int8    `db:"int8_" json:"int8_"`
+	Float32 float32 `db:"float32_" json:"float32_"`
+	String  string  `db:"string_" json:"string_"`
+	Binary  []byte  `db:"binary_" json:"binary_"`
+	Byte    byte    `db:"byte_" json:"byte_"`
+	Rune    rune    `db:"rune_" json:"rune_"`
+	Bool    bool    `db:"bool_" json:"bool_"`
+}
+
+func (t *DefaultValue) Args() []interface{} {
+	return []interface{}{&t.ID, &t.Int8, &t.Float32, &t.String, &t.Binary, &t.Byte, &t.Rune, &t.Bool}
+}
+
+func (t *DefaultValue) StmtInsert() (*sql.Stmt, error) { return Insert.Get(2) }
+
+func (t *DefaultValue) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 2, t.Args()...)
+}
+
+// BatchInsertDefaultValue inserts several rows within a transaction.
+func BatchInsertDefaultValue(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*DefaultValue) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "default_value", new(DefaultValue).Columns(), args)
 }
 
-func (t *Types) StmtInsert() *sql.Stmt { return Insert.Stmt[0] }
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (id) already exists.
+func (t *DefaultValue) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(2) }
 
-type Default_value struct {
-	Id       int
-	Int8_    int8
-	Float32_ float32
-	String_  string
-	Binary_  []byte
-	Byte_    byte
-	Rune_    rune
-	Bool_    bool
+// Upsert inserts the data, or updates it if the key (id) already exists.
+func (t *DefaultValue) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 2, t.Args()...)
 }
 
-func (t *Default_value) Args() []interface{} {
-	return []interface{}{&t.Id, &t.Int8_, &t.Float32_, &t.String_, &t.Binary_, &t.Byte_, &t.Rune_, &t.Bool_}
+// Columns returns the name of the columns, in the same order than Args.
+func (t *DefaultValue) Columns() []string {
+	return []string{"id", "int8_", "float32_", "string_", "binary_", "byte_", "rune_", "bool_"}
 }
 
-func (t *Default_value) StmtInsert() *sql.Stmt { return Insert.Stmt[1] }
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *DefaultValue) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "id":
+			dest[i] = &t.ID
+		case "int8_":
+			dest[i] = &t.Int8
+		case "float32_":
+			dest[i] = &t.Float32
+		case "string_":
+			dest[i] = &t.String
+		case "binary_":
+			dest[i] = &t.Binary
+		case "byte_":
+			dest[i] = &t.Byte
+		case "rune_":
+			dest[i] = &t.Rune
+		case "bool_":
+			dest[i] = &t.Bool
+		default:
+			v, err := modsql.UnknownColumn("default_value", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *DefaultValue) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetDefaultValueByPK returns the row of default_value with the given primary key.
+func GetDefaultValueByPK(ctx context.Context, q modsql.Querier, id int) (*DefaultValue, error) {
+	t := new(DefaultValue)
+	err := SelectByPK.QueryRowContext(ctx, q, 2, id).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *DefaultValue) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 2, &t.ID)
+}
+
+func (t *DefaultValue) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 2, &t.ID).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *DefaultValue) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 2, &t.Int8, &t.Float32, &t.String, &t.Binary, &t.Byte, &t.Rune, &t.Bool, &t.ID)
+}
 
 type Times struct {
-	TypeId   int
-	Datetime time.Time
+	TypeID   int       `db:"typeId" json:"typeId"`
+	DateTime time.Time `db:"datetime" json:"datetime"`
 }
 
 func (t *Times) Args() []interface{} {
-	return []interface{}{&t.TypeId, &t.Datetime}
+	return []interface{}{&t.TypeID, &t.DateTime}
+}
+
+func (t *Times) StmtInsert() (*sql.Stmt, error) { return Insert.Get(3) }
+
+func (t *Times) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 3, t.Args()...)
+}
+
+// BatchInsertTimes inserts several rows within a transaction.
+func BatchInsertTimes(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*Times) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "times", new(Times).Columns(), args)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *Times) Columns() []string {
+	return []string{"typeId", "datetime"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *Times) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "typeid":
+			dest[i] = &t.TypeID
+		case "datetime":
+			dest[i] = &t.DateTime
+		default:
+			v, err := modsql.UnknownColumn("times", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *Times) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// Notes attached to the tenant.
+type Note struct {
+	NoteID int `db:"note_id" json:"note_id"`
+	// Text written by the user, in Markdown; it's not sanitized.
+	Body     string `db:"body" json:"body"`
+	TenantID int    `db:"tenant_id" json:"tenant_id"`
+	Audit
+	CreatedAt time.Time    `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time    `db:"updated_at" json:"updated_at"`
+	DeletedAt sql.NullTime `db:"deleted_at" json:"deleted_at"`
+}
+
+// Audit has the columns of the mixin "audit".
+type Audit struct {
+	CreatedBy string `db:"created_by" json:"created_by"`
+	UpdatedBy string `db:"updated_by" json:"updated_by"`
+}
+
+func (t *Note) Args() []interface{} {
+	return []interface{}{&t.NoteID, &t.Body, &t.TenantID, &t.CreatedBy, &t.UpdatedBy, &t.CreatedAt, &t.UpdatedAt, &t.DeletedAt}
+}
+
+// insertArgs returns the arguments to insert a row, without the columns
+// set by the database.
+func (t *Note) insertArgs() []interface{} {
+	return []interface{}{&t.NoteID, &t.Body, &t.TenantID, &t.CreatedBy, &t.UpdatedBy}
+}
+
+func (t *Note) StmtInsert() (*sql.Stmt, error) { return Insert.Get(4) }
+
+func (t *Note) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 4, t.insertArgs()...)
+}
+
+// BatchInsertNote inserts several rows within a transaction.
+func BatchInsertNote(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*Note) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.insertArgs()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "note", []string{"note_id", "body", "tenant_id", "created_by", "updated_by"}, args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (note_id) already exists.
+func (t *Note) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(4) }
+
+// Upsert inserts the data, or updates it if the key (note_id) already exists.
+func (t *Note) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 4, t.insertArgs()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *Note) Columns() []string {
+	return []string{"note_id", "body", "tenant_id", "created_by", "updated_by", "created_at", "updated_at", "deleted_at"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *Note) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "note_id":
+			dest[i] = &t.NoteID
+		case "body":
+			dest[i] = &t.Body
+		case "tenant_id":
+			dest[i] = &t.TenantID
+		case "created_by":
+			dest[i] = &t.CreatedBy
+		case "updated_by":
+			dest[i] = &t.UpdatedBy
+		case "created_at":
+			dest[i] = &t.CreatedAt
+		case "updated_at":
+			dest[i] = &t.UpdatedAt
+		case "deleted_at":
+			dest[i] = &t.DeletedAt
+		default:
+			v, err := modsql.UnknownColumn("note", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *Note) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
 }
 
-func (t *Times) StmtInsert() *sql.Stmt { return Insert.Stmt[2] }
+// GetNoteByPK returns the row of note with the given primary key.
+func GetNoteByPK(ctx context.Context, q modsql.Querier, noteID int) (*Note, error) {
+	t := new(Note)
+	err := SelectByPK.QueryRowContext(ctx, q, 4, noteID).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Note) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 4, &t.NoteID)
+}
+
+func (t *Note) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 4, &t.NoteID).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *Note) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 4, &t.Body, &t.TenantID, &t.CreatedBy, &t.UpdatedBy, &t.NoteID)
+}
 
 type Account struct {
-	Acc_num   int
-	Acc_type  int
-	Acc_descr string
+	AccNum   int    `db:"acc_num" json:"acc_num"`
+	AccType  int    `db:"acc_type" json:"acc_type"`
+	AccDescr string `db:"acc_descr" json:"acc_descr"`
 }
 
 func (t *Account) Args() []interface{} {
-	return []interface{}{&t.Acc_num, &t.Acc_type, &t.Acc_descr}
+	return []interface{}{&t.AccNum, &t.AccType, &t.AccDescr}
+}
+
+func (t *Account) StmtInsert() (*sql.Stmt, error) { return Insert.Get(5) }
+
+func (t *Account) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 5, t.Args()...)
+}
+
+// BatchInsertAccount inserts several rows within a transaction.
+func BatchInsertAccount(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*Account) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "account", new(Account).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (acc_num, acc_type) already exists.
+//
+// In MySQL, it is also updated on a conflict with the other unique keys (acc_type, acc_descr).
+func (t *Account) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(5) }
+
+// Upsert inserts the data, or updates it if the key (acc_num, acc_type) already exists.
+//
+// In MySQL, it is also updated on a conflict with the other unique keys (acc_type, acc_descr).
+func (t *Account) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 5, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *Account) Columns() []string {
+	return []string{"acc_num", "acc_type", "acc_descr"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *Account) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "acc_num":
+			dest[i] = &t.AccNum
+		case "acc_type":
+			dest[i] = &t.AccType
+		case "acc_descr":
+			dest[i] = &t.AccDescr
+		default:
+			v, err := modsql.UnknownColumn("account", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *Account) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetAccountByPK returns the row of account with the given primary key.
+func GetAccountByPK(ctx context.Context, q modsql.Querier, accNum int, accType int) (*Account, error) {
+	t := new(Account)
+	err := SelectByPK.QueryRowContext(ctx, q, 5, accNum, accType).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
 }
 
-func (t *Account) StmtInsert() *sql.Stmt { return Insert.Stmt[3] }
+func (t *Account) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 5, &t.AccNum, &t.AccType)
+}
+
+func (t *Account) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 5, &t.AccNum, &t.AccType).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *Account) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 5, &t.AccDescr, &t.AccNum, &t.AccType)
+}
 
-type Sub_account struct {
-	Sub_acc   int
-	Ref_num   int
-	Ref_type  int
-	Sub_descr string
+type SubAccount struct {
+	SubAcc   int    `db:"sub_acc" json:"sub_acc"`
+	RefNum   int    `db:"ref_num" json:"ref_num"`
+	RefType  int    `db:"ref_type" json:"ref_type"`
+	SubDescr string `db:"sub_descr" json:"sub_descr"`
 }
 
-func (t *Sub_account) Args() []interface{} {
-	return []interface{}{&t.Sub_acc, &t.Ref_num, &t.Ref_type, &t.Sub_descr}
+func (t *SubAccount) Args() []interface{} {
+	return []interface{}{&t.SubAcc, &t.RefNum, &t.RefType, &t.SubDescr}
 }
 
-func (t *Sub_account) StmtInsert() *sql.Stmt { return Insert.Stmt[4] }
+func (t *SubAccount) StmtInsert() (*sql.Stmt, error) { return Insert.Get(6) }
+
+func (t *SubAccount) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 6, t.Args()...)
+}
+
+// BatchInsertSubAccount inserts several rows within a transaction.
+func BatchInsertSubAccount(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*SubAccount) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "sub_account", new(SubAccount).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (sub_acc) already exists.
+func (t *SubAccount) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(6) }
+
+// Upsert inserts the data, or updates it if the key (sub_acc) already exists.
+func (t *SubAccount) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 6, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *SubAccount) Columns() []string {
+	return []string{"sub_acc", "ref_num", "ref_type", "sub_descr"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *SubAccount) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "sub_acc":
+			dest[i] = &t.SubAcc
+		case "ref_num":
+			dest[i] = &t.RefNum
+		case "ref_type":
+			dest[i] = &t.RefType
+		case "sub_descr":
+			dest[i] = &t.SubDescr
+		default:
+			v, err := modsql.UnknownColumn("sub_account", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *SubAccount) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetSubAccountByPK returns the row of sub_account with the given primary key.
+func GetSubAccountByPK(ctx context.Context, q modsql.Querier, subAcc int) (*SubAccount, error) {
+	t := new(SubAccount)
+	err := SelectByPK.QueryRowContext(ctx, q, 6, subAcc).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *SubAccount) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 6, &t.SubAcc)
+}
+
+func (t *SubAccount) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 6, &t.SubAcc).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *SubAccount) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 6, &t.RefNum, &t.RefType, &t.SubDescr, &t.SubAcc)
+}
 
 type Catalog struct {
-	Catalog_id  int
-	Name        string
-	Description string
-	Price       float32
+	CatalogID   int     `db:"catalog_id" json:"catalog_id"`
+	Name        string  `db:"name" json:"name"`
+	Description string  `db:"description" json:"description,omitempty"`
+	Price       float32 `db:"price" json:"price"`
 }
 
 func (t *Catalog) Args() []interface{} {
-	return []interface{}{&t.Catalog_id, &t.Name, &t.Description, &t.Price}
+	return []interface{}{&t.CatalogID, &t.Name, &t.Description, &t.Price}
+}
+
+func (t *Catalog) StmtInsert() (*sql.Stmt, error) { return Insert.Get(7) }
+
+func (t *Catalog) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 7, t.Args()...)
+}
+
+// BatchInsertCatalog inserts several rows within a transaction.
+func BatchInsertCatalog(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*Catalog) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "catalog", new(Catalog).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (catalog_id) already exists.
+func (t *Catalog) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(7) }
+
+// Upsert inserts the data, or updates it if the key (catalog_id) already exists.
+func (t *Catalog) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 7, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *Catalog) Columns() []string {
+	return []string{"catalog_id", "name", "description", "price"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *Catalog) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "catalog_id":
+			dest[i] = &t.CatalogID
+		case "name":
+			dest[i] = &t.Name
+		case "description":
+			dest[i] = &t.Description
+		case "price":
+			dest[i] = &t.Price
+		default:
+			v, err := modsql.UnknownColumn("catalog", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *Catalog) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetCatalogByPK returns the row of catalog with the given primary key.
+func GetCatalogByPK(ctx context.Context, q modsql.Querier, catalogID int) (*Catalog, error) {
+	t := new(Catalog)
+	err := SelectByPK.QueryRowContext(ctx, q, 7, catalogID).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Catalog) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 7, &t.CatalogID)
+}
+
+func (t *Catalog) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 7, &t.CatalogID).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *Catalog) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 7, &t.Name, &t.Description, &t.Price, &t.CatalogID)
 }
 
-func (t *Catalog) StmtInsert() *sql.Stmt { return Insert.Stmt[5] }
+// Validate checks that the strings are not longer than the size of their column.
+func (t *Catalog) Validate() error {
+	if err := modsql.CheckLength("catalog", "name", t.Name, 60); err != nil {
+		return err
+	}
+	return nil
+}
 
 type Magazine struct {
-	Catalog_id int
-	Page_count string
+	CatalogID int    `db:"catalog_id" json:"catalog_id"`
+	PageCount string `db:"page_count" json:"page_count"`
 }
 
 func (t *Magazine) Args() []interface{} {
-	return []interface{}{&t.Catalog_id, &t.Page_count}
+	return []interface{}{&t.CatalogID, &t.PageCount}
+}
+
+func (t *Magazine) StmtInsert() (*sql.Stmt, error) { return Insert.Get(8) }
+
+func (t *Magazine) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 8, t.Args()...)
+}
+
+// BatchInsertMagazine inserts several rows within a transaction.
+func BatchInsertMagazine(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*Magazine) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "magazine", new(Magazine).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (catalog_id) already exists.
+func (t *Magazine) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(8) }
+
+// Upsert inserts the data, or updates it if the key (catalog_id) already exists.
+func (t *Magazine) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 8, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *Magazine) Columns() []string {
+	return []string{"catalog_id", "page_count"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *Magazine) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "catalog_id":
+			dest[i] = &t.CatalogID
+		case "page_count":
+			dest[i] = &t.PageCount
+		default:
+			v, err := modsql.UnknownColumn("magazine", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *Magazine) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetMagazineByPK returns the row of magazine with the given primary key.
+func GetMagazineByPK(ctx context.Context, q modsql.Querier, catalogID int) (*Magazine, error) {
+	t := new(Magazine)
+	err := SelectByPK.QueryRowContext(ctx, q, 8, catalogID).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Magazine) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 8, &t.CatalogID)
 }
 
-func (t *Magazine) StmtInsert() *sql.Stmt { return Insert.Stmt[6] }
+func (t *Magazine) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 8, &t.CatalogID).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *Magazine) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 8, &t.PageCount, &t.CatalogID)
+}
 
 type Mp3 struct {
-	Catalog_id int
-	Size       int
-	Length     float32
-	Filename   string
+	CatalogID int     `db:"catalog_id" json:"catalog_id"`
+	Size      int     `db:"size" json:"size"`
+	Length    float32 `db:"length" json:"length"`
+	Filename  string  `db:"filename" json:"filename"`
 }
 
 func (t *Mp3) Args() []interface{} {
-	return []interface{}{&t.Catalog_id, &t.Size, &t.Length, &t.Filename}
+	return []interface{}{&t.CatalogID, &t.Size, &t.Length, &t.Filename}
+}
+
+func (t *Mp3) StmtInsert() (*sql.Stmt, error) { return Insert.Get(9) }
+
+func (t *Mp3) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 9, t.Args()...)
+}
+
+// BatchInsertMp3 inserts several rows within a transaction.
+func BatchInsertMp3(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*Mp3) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "mp3", new(Mp3).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (catalog_id) already exists.
+func (t *Mp3) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(9) }
+
+// Upsert inserts the data, or updates it if the key (catalog_id) already exists.
+func (t *Mp3) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 9, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *Mp3) Columns() []string {
+	return []string{"catalog_id", "size", "length", "filename"}
 }
 
-func (t *Mp3) StmtInsert() *sql.Stmt { return Insert.Stmt[7] }
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *Mp3) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "catalog_id":
+			dest[i] = &t.CatalogID
+		case "size":
+			dest[i] = &t.Size
+		case "length":
+			dest[i] = &t.Length
+		case "filename":
+			dest[i] = &t.Filename
+		default:
+			v, err := modsql.UnknownColumn("mp3", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *Mp3) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetMp3ByPK returns the row of mp3 with the given primary key.
+func GetMp3ByPK(ctx context.Context, q modsql.Querier, catalogID int) (*Mp3, error) {
+	t := new(Mp3)
+	err := SelectByPK.QueryRowContext(ctx, q, 9, catalogID).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Mp3) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 9, &t.CatalogID)
+}
+
+func (t *Mp3) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 9, &t.CatalogID).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *Mp3) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 9, &t.Size, &t.Length, &t.Filename, &t.CatalogID)
+}
 
 type Book struct {
-	Book_id int
-	Title   string
-	Author  string
+	BookID int    `db:"book_id" json:"book_id"`
+	Title  string `db:"title" json:"title"`
+	Author string `db:"author" json:"author"`
 }
 
 func (t *Book) Args() []interface{} {
-	return []interface{}{&t.Book_id, &t.Title, &t.Author}
+	return []interface{}{&t.BookID, &t.Title, &t.Author}
+}
+
+func (t *Book) StmtInsert() (*sql.Stmt, error) { return Insert.Get(10) }
+
+func (t *Book) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 10, t.Args()...)
+}
+
+// BatchInsertBook inserts several rows within a transaction.
+func BatchInsertBook(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*Book) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "book", new(Book).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (book_id) already exists.
+func (t *Book) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(10) }
+
+// Upsert inserts the data, or updates it if the key (book_id) already exists.
+func (t *Book) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 10, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *Book) Columns() []string {
+	return []string{"book_id", "title", "author"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *Book) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "book_id":
+			dest[i] = &t.BookID
+		case "title":
+			dest[i] = &t.Title
+		case "author":
+			dest[i] = &t.Author
+		default:
+			v, err := modsql.UnknownColumn("book", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *Book) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
 }
 
-func (t *Book) StmtInsert() *sql.Stmt { return Insert.Stmt[8] }
+// GetBookByPK returns the row of book with the given primary key.
+func GetBookByPK(ctx context.Context, q modsql.Querier, bookID int) (*Book, error) {
+	t := new(Book)
+	err := SelectByPK.QueryRowContext(ctx, q, 10, bookID).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Book) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 10, &t.BookID)
+}
+
+func (t *Book) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 10, &t.BookID).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *Book) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 10, &t.Title, &t.Author, &t.BookID)
+}
 
 type Chapter struct {
-	Chapter_id int
-	Title      string
-	Book_fk    int
+	ChapterID int    `db:"chapter_id" json:"chapter_id"`
+	Title     string `db:"title" json:"title"`
+	BookFk    int    `db:"book_fk" json:"book_fk"`
 }
 
 func (t *Chapter) Args() []interface{} {
-	return []interface{}{&t.Chapter_id, &t.Title, &t.Book_fk}
+	return []interface{}{&t.ChapterID, &t.Title, &t.BookFk}
+}
+
+func (t *Chapter) StmtInsert() (*sql.Stmt, error) { return Insert.Get(11) }
+
+func (t *Chapter) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 11, t.Args()...)
+}
+
+// BatchInsertChapter inserts several rows within a transaction.
+func BatchInsertChapter(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*Chapter) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "chapter", new(Chapter).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (chapter_id) already exists.
+func (t *Chapter) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(11) }
+
+// Upsert inserts the data, or updates it if the key (chapter_id) already exists.
+func (t *Chapter) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 11, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *Chapter) Columns() []string {
+	return []string{"chapter_id", "title", "book_fk"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *Chapter) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "chapter_id":
+			dest[i] = &t.ChapterID
+		case "title":
+			dest[i] = &t.Title
+		case "book_fk":
+			dest[i] = &t.BookFk
+		default:
+			v, err := modsql.UnknownColumn("chapter", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
 }
 
-func (t *Chapter) StmtInsert() *sql.Stmt { return Insert.Stmt[9] }
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *Chapter) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetChapterByPK returns the row of chapter with the given primary key.
+func GetChapterByPK(ctx context.Context, q modsql.Querier, chapterID int) (*Chapter, error) {
+	t := new(Chapter)
+	err := SelectByPK.QueryRowContext(ctx, q, 11, chapterID).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Chapter) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 11, &t.ChapterID)
+}
+
+func (t *Chapter) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 11, &t.ChapterID).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *Chapter) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 11, &t.Title, &t.BookFk, &t.ChapterID)
+}
 
 type User struct {
-	User_id    int
-	First_name string
-	Last_name  string
+	UserID    int    `db:"user_id" json:"user_id"`
+	FirstName string `db:"first_name" json:"first_name"`
+	LastName  string `db:"last_name" json:"last_name"`
 }
 
 func (t *User) Args() []interface{} {
-	return []interface{}{&t.User_id, &t.First_name, &t.Last_name}
+	return []interface{}{&t.UserID, &t.FirstName, &t.LastName}
+}
+
+func (t *User) StmtInsert() (*sql.Stmt, error) { return Insert.Get(12) }
+
+func (t *User) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 12, t.Args()...)
 }
 
-func (t *User) StmtInsert() *sql.Stmt { return Insert.Stmt[10] }
+// BatchInsertUser inserts several rows within a transaction.
+func BatchInsertUser(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*User) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "user", new(User).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (user_id) already exists.
+func (t *User) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(12) }
+
+// Upsert inserts the data, or updates it if the key (user_id) already exists.
+func (t *User) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 12, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *User) Columns() []string {
+	return []string{"user_id", "first_name", "last_name"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *User) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "user_id":
+			dest[i] = &t.UserID
+		case "first_name":
+			dest[i] = &t.FirstName
+		case "last_name":
+			dest[i] = &t.LastName
+		default:
+			v, err := modsql.UnknownColumn("user", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *User) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetUserByPK returns the row of user with the given primary key.
+func GetUserByPK(ctx context.Context, q modsql.Querier, userID int) (*User, error) {
+	t := new(User)
+	err := SelectByPK.QueryRowContext(ctx, q, 12, userID).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *User) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 12, &t.UserID)
+}
+
+func (t *User) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 12, &t.UserID).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *User) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 12, &t.FirstName, &t.LastName, &t.UserID)
+}
 
 type Address struct {
-	Address_id int
-	Street     string
-	City       string
-	State      string
-	Post_code  string
+	AddressID int    `db:"address_id" json:"address_id"`
+	Street    string `db:"street" json:"street"`
+	City      string `db:"city" json:"city"`
+	State     string `db:"state" json:"state"`
+	PostCode  string `db:"post_code" json:"post_code"`
 }
 
 func (t *Address) Args() []interface{} {
-	return []interface{}{&t.Address_id, &t.Street, &t.City, &t.State, &t.Post_code}
+	return []interface{}{&t.AddressID, &t.Street, &t.City, modsql.CharField{S: &t.State}, &t.PostCode}
+}
+
+func (t *Address) StmtInsert() (*sql.Stmt, error) { return Insert.Get(13) }
+
+func (t *Address) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 13, t.Args()...)
+}
+
+// BatchInsertAddress inserts several rows within a transaction.
+func BatchInsertAddress(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*Address) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "address", new(Address).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (address_id) already exists.
+func (t *Address) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(13) }
+
+// Upsert inserts the data, or updates it if the key (address_id) already exists.
+func (t *Address) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 13, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *Address) Columns() []string {
+	return []string{"address_id", "street", "city", "state", "post_code"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *Address) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "address_id":
+			dest[i] = &t.AddressID
+		case "street":
+			dest[i] = &t.Street
+		case "city":
+			dest[i] = &t.City
+		case "state":
+			dest[i] = modsql.CharField{S: &t.State}
+		case "post_code":
+			dest[i] = &t.PostCode
+		default:
+			v, err := modsql.UnknownColumn("address", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *Address) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetAddressByPK returns the row of address with the given primary key.
+func GetAddressByPK(ctx context.Context, q modsql.Querier, addressID int) (*Address, error) {
+	t := new(Address)
+	err := SelectByPK.QueryRowContext(ctx, q, 13, addressID).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Address) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 13, &t.AddressID)
+}
+
+func (t *Address) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 13, &t.AddressID).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *Address) Update(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Update.ExecContext(ctx, q, 13, &t.Street, &t.City, modsql.CharField{S: &t.State}, &t.PostCode, &t.AddressID)
+}
+
+// Validate checks that the strings are not longer than the size of their column.
+func (t *Address) Validate() error {
+	if err := modsql.CheckLength("address", "state", t.State, 2); err != nil {
+		return err
+	}
+	return nil
+}
+
+type UserAddress struct {
+	UserID    int `db:"user_id" json:"user_id"`
+	AddressID int `db:"address_id" json:"address_id"`
+}
+
+func (t *UserAddress) Args() []interface{} {
+	return []interface{}{&t.UserID, &t.AddressID}
+}
+
+func (t *UserAddress) StmtInsert() (*sql.Stmt, error) { return Insert.Get(14) }
+
+func (t *UserAddress) Insert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Insert.ExecContext(ctx, q, 14, t.Args()...)
+}
+
+// BatchInsertUserAddress inserts several rows within a transaction.
+func BatchInsertUserAddress(ctx context.Context, q modsql.Querier, opts *modsql.BatchOptions, rows ...*UserAddress) error {
+	args := make([][]interface{}, len(rows))
+	for i, v := range rows {
+		args[i] = v.Args()
+	}
+	return modsql.BatchInsert(ctx, q, ENGINE, opts, "user_address", new(UserAddress).Columns(), args)
+}
+
+// StmtUpsert returns the prepared statement to insert data, or to update
+// it if the key (user_id, address_id) already exists.
+func (t *UserAddress) StmtUpsert() (*sql.Stmt, error) { return Upsert.Get(14) }
+
+// Upsert inserts the data, or updates it if the key (user_id, address_id) already exists.
+func (t *UserAddress) Upsert(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Upsert.ExecContext(ctx, q, 14, t.Args()...)
+}
+
+// Columns returns the name of the columns, in the same order than Args.
+func (t *UserAddress) Columns() []string {
+	return []string{"user_id", "address_id"}
+}
+
+// ScanColumns returns the destination to scan every one of the given columns.
+func (t *UserAddress) ScanColumns(cols []string, opts ...modsql.ScanOption) ([]interface{}, error) {
+	dest := make([]interface{}, len(cols))
+
+	for i, col := range cols {
+		switch strings.ToLower(col) {
+		case "user_id":
+			dest[i] = &t.UserID
+		case "address_id":
+			dest[i] = &t.AddressID
+		default:
+			v, err := modsql.UnknownColumn("user_address", col, opts...)
+			if err != nil {
+				return nil, err
+			}
+			dest[i] = v
+		}
+	}
+	return dest, nil
+}
+
+// Scan copies the columns in the current row into the fields with the same name.
+func (t *UserAddress) Scan(rows *sql.Rows, opts ...modsql.ScanOption) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	dest, err := t.ScanColumns(cols, opts...)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// GetUserAddressByPK returns the row of user_address with the given primary key.
+func GetUserAddressByPK(ctx context.Context, q modsql.Querier, userID int, addressID int) (*UserAddress, error) {
+	t := new(UserAddress)
+	err := SelectByPK.QueryRowContext(ctx, q, 14, userID, addressID).Scan(t.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *UserAddress) Delete(ctx context.Context, q modsql.Querier) (sql.Result, error) {
+	return Delete.ExecContext(ctx, q, 14, &t.UserID, &t.AddressID)
+}
+
+func (t *UserAddress) Exists(ctx context.Context, q modsql.Querier) (bool, error) {
+	var found int
+	switch err := Exists.QueryRowContext(ctx, q, 14, &t.UserID, &t.AddressID).Scan(&found); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Relations has the foreign keys between the tables.
+var Relations = []modsql.Relation{
+	{Table: "person", Columns: []string{"sex"}, RefTable: "sex", RefColumns: []string{"id"}},
+	{Table: "sub_account", Columns: []string{"ref_num", "ref_type"}, RefTable: "account", RefColumns: []string{"acc_num", "acc_type"}},
+	{Table: "magazine", Columns: []string{"catalog_id"}, RefTable: "catalog", RefColumns: []string{"catalog_id"}},
+	{Table: "mp3", Columns: []string{"catalog_id"}, RefTable: "catalog", RefColumns: []string{"catalog_id"}},
+	{Table: "chapter", Columns: []string{"book_fk"}, RefTable: "book", RefColumns: []string{"book_id"}},
+	{Table: "user_address", Columns: []string{"user_id"}, RefTable: "user", RefColumns: []string{"user_id"}},
+	{Table: "user_address", Columns: []string{"address_id"}, RefTable: "address", RefColumns: []string{"address_id"}},
+}
+
+// Account returns the row of account referenced by the foreign key (ref_num, ref_type).
+func (t *SubAccount) Account(ctx context.Context, q modsql.Querier) (*Account, error) {
+	v := new(Account)
+	if err := SelectRelated.QueryRowContext(ctx, q, 0, t.RefNum, t.RefType).Scan(v.Args()...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// SubAccounts returns the rows of sub_account which refer to this row.
+func (t *Account) SubAccounts(ctx context.Context, q modsql.Querier) ([]*SubAccount, error) {
+	rows, err := SelectRelated.QueryContext(ctx, q, 1, t.AccNum, t.AccType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*SubAccount
+	for rows.Next() {
+		v := new(SubAccount)
+		if err = rows.Scan(v.Args()...); err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, rows.Err()
+}
+
+// Catalog returns the row of catalog referenced by the foreign key (catalog_id).
+func (t *Magazine) Catalog(ctx context.Context, q modsql.Querier) (*Catalog, error) {
+	v := new(Catalog)
+	if err := SelectRelated.QueryRowContext(ctx, q, 2, t.CatalogID).Scan(v.Args()...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Magazine returns the row of magazine which refers to this row.
+func (t *Catalog) Magazine(ctx context.Context, q modsql.Querier) (*Magazine, error) {
+	v := new(Magazine)
+	if err := SelectRelated.QueryRowContext(ctx, q, 3, t.CatalogID).Scan(v.Args()...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// LoadCatalogsForMagazines returns the rows of catalog referenced by the given rows,
+// by the value of catalog_id.
+func LoadCatalogsForMagazines(ctx context.Context, q modsql.Querier, rows []*Magazine) (map[int]*Catalog, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.CatalogID
+	}
+
+	res := make(map[int]*Catalog)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT catalog_id, name, description, price FROM catalog WHERE catalog_id", keys, func(r *sql.Rows) error {
+		v := new(Catalog)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.CatalogID] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LoadMagazinesForCatalogs returns the rows of magazine which refer to the given rows,
+// by the value of catalog_id.
+func LoadMagazinesForCatalogs(ctx context.Context, q modsql.Querier, rows []*Catalog) (map[int]*Magazine, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.CatalogID
+	}
+
+	res := make(map[int]*Magazine)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT catalog_id, page_count FROM magazine WHERE catalog_id", keys, func(r *sql.Rows) error {
+		v := new(Magazine)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.CatalogID] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
-func (t *Address) StmtInsert() *sql.Stmt { return Insert.Stmt[11] }
+// Catalog returns the row of catalog referenced by the foreign key (catalog_id).
+func (t *Mp3) Catalog(ctx context.Context, q modsql.Querier) (*Catalog, error) {
+	v := new(Catalog)
+	if err := SelectRelated.QueryRowContext(ctx, q, 4, t.CatalogID).Scan(v.Args()...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Mp3 returns the row of mp3 which refers to this row.
+func (t *Catalog) Mp3(ctx context.Context, q modsql.Querier) (*Mp3, error) {
+	v := new(Mp3)
+	if err := SelectRelated.QueryRowContext(ctx, q, 5, t.CatalogID).Scan(v.Args()...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// LoadCatalogsForMp3s returns the rows of catalog referenced by the given rows,
+// by the value of catalog_id.
+func LoadCatalogsForMp3s(ctx context.Context, q modsql.Querier, rows []*Mp3) (map[int]*Catalog, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.CatalogID
+	}
+
+	res := make(map[int]*Catalog)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT catalog_id, name, description, price FROM catalog WHERE catalog_id", keys, func(r *sql.Rows) error {
+		v := new(Catalog)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.CatalogID] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LoadMp3sForCatalogs returns the rows of mp3 which refer to the given rows,
+// by the value of catalog_id.
+func LoadMp3sForCatalogs(ctx context.Context, q modsql.Querier, rows []*Catalog) (map[int]*Mp3, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.CatalogID
+	}
+
+	res := make(map[int]*Mp3)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT catalog_id, size, length, filename FROM mp3 WHERE catalog_id", keys, func(r *sql.Rows) error {
+		v := new(Mp3)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.CatalogID] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Book returns the row of book referenced by the foreign key (book_fk).
+func (t *Chapter) Book(ctx context.Context, q modsql.Querier) (*Book, error) {
+	v := new(Book)
+	if err := SelectRelated.QueryRowContext(ctx, q, 6, t.BookFk).Scan(v.Args()...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Chapters returns the rows of chapter which refer to this row.
+func (t *Book) Chapters(ctx context.Context, q modsql.Querier) ([]*Chapter, error) {
+	rows, err := SelectRelated.QueryContext(ctx, q, 7, t.BookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*Chapter
+	for rows.Next() {
+		v := new(Chapter)
+		if err = rows.Scan(v.Args()...); err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, rows.Err()
+}
+
+// LoadBooksForChapters returns the rows of book referenced by the given rows,
+// by the value of book_id.
+func LoadBooksForChapters(ctx context.Context, q modsql.Querier, rows []*Chapter) (map[int]*Book, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.BookFk
+	}
+
+	res := make(map[int]*Book)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT book_id, title, author FROM book WHERE book_id", keys, func(r *sql.Rows) error {
+		v := new(Book)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.BookID] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LoadChaptersForBooks returns the rows of chapter which refer to the given rows,
+// by the value of book_fk.
+func LoadChaptersForBooks(ctx context.Context, q modsql.Querier, rows []*Book) (map[int][]*Chapter, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.BookID
+	}
+
+	res := make(map[int][]*Chapter)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT chapter_id, title, book_fk FROM chapter WHERE book_fk", keys, func(r *sql.Rows) error {
+		v := new(Chapter)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.BookFk] = append(res[v.BookFk], v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// User returns the row of user referenced by the foreign key (user_id).
+func (t *UserAddress) User(ctx context.Context, q modsql.Querier) (*User, error) {
+	v := new(User)
+	if err := SelectRelated.QueryRowContext(ctx, q, 8, t.UserID).Scan(v.Args()...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// UserAddresses returns the rows of user_address which refer to this row.
+func (t *User) UserAddresses(ctx context.Context, q modsql.Querier) ([]*UserAddress, error) {
+	rows, err := SelectRelated.QueryContext(ctx, q, 9, t.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*UserAddress
+	for rows.Next() {
+		v := new(UserAddress)
+		if err = rows.Scan(v.Args()...); err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, rows.Err()
+}
+
+// LoadUsersForUserAddresses returns the rows of user referenced by the given rows,
+// by the value of user_id.
+func LoadUsersForUserAddresses(ctx context.Context, q modsql.Querier, rows []*UserAddress) (map[int]*User, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.UserID
+	}
+
+	res := make(map[int]*User)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT user_id, first_name, last_name FROM {Q}user{Q} WHERE user_id", keys, func(r *sql.Rows) error {
+		v := new(User)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.UserID] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
-type User_address struct {
-	User_id    int
-	Address_id int
+// LoadUserAddressesForUsers returns the rows of user_address which refer to the given rows,
+// by the value of user_id.
+func LoadUserAddressesForUsers(ctx context.Context, q modsql.Querier, rows []*User) (map[int][]*UserAddress, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.UserID
+	}
+
+	res := make(map[int][]*UserAddress)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT user_id, address_id FROM user_address WHERE user_id", keys, func(r *sql.Rows) error {
+		v := new(UserAddress)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.UserID] = append(res[v.UserID], v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Address returns the row of address referenced by the foreign key (address_id).
+func (t *UserAddress) Address(ctx context.Context, q modsql.Querier) (*Address, error) {
+	v := new(Address)
+	if err := SelectRelated.QueryRowContext(ctx, q, 10, t.AddressID).Scan(v.Args()...); err != nil {
+		return nil, err
+	}
+	return v, nil
 }
 
-func (t *User_address) Args() []interface{} {
-	return []interface{}{&t.User_id, &t.Address_id}
+// UserAddresses returns the rows of user_address which refer to this row.
+func (t *Address) UserAddresses(ctx context.Context, q modsql.Querier) ([]*UserAddress, error) {
+	rows, err := SelectRelated.QueryContext(ctx, q, 11, t.AddressID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*UserAddress
+	for rows.Next() {
+		v := new(UserAddress)
+		if err = rows.Scan(v.Args()...); err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, rows.Err()
 }
 
-func (t *User_address) StmtInsert() *sql.Stmt { return Insert.Stmt[12] }
+// LoadAddressesForUserAddresses returns the rows of address referenced by the given rows,
+// by the value of address_id.
+func LoadAddressesForUserAddresses(ctx context.Context, q modsql.Querier, rows []*UserAddress) (map[int]*Address, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.AddressID
+	}
+
+	res := make(map[int]*Address)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT address_id, street, city, state, post_code FROM address WHERE address_id", keys, func(r *sql.Rows) error {
+		v := new(Address)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.AddressID] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LoadUserAddressesForAddresses returns the rows of user_address which refer to the given rows,
+// by the value of address_id.
+func LoadUserAddressesForAddresses(ctx context.Context, q modsql.Querier, rows []*Address) (map[int][]*UserAddress, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.AddressID
+	}
+
+	res := make(map[int][]*UserAddress)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT user_id, address_id FROM user_address WHERE address_id", keys, func(r *sql.Rows) error {
+		v := new(UserAddress)
+		if err := r.Scan(v.Args()...); err != nil {
+			return err
+		}
+		res[v.AddressID] = append(res[v.AddressID], v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Addresses returns the rows of address related to this row through user_address.
+func (t *User) Addresses(ctx context.Context, q modsql.Querier) ([]*Address, error) {
+	rows, err := SelectRelated.QueryContext(ctx, q, 12, t.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*Address
+	for rows.Next() {
+		v := new(Address)
+		if err = rows.Scan(v.Args()...); err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, rows.Err()
+}
+
+// LoadAddressesForUsers returns the rows of address related to the given rows through
+// user_address, by the value of user_id.
+func LoadAddressesForUsers(ctx context.Context, q modsql.Querier, rows []*User) (map[int][]*Address, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.UserID
+	}
+
+	res := make(map[int][]*Address)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT address.address_id, address.street, address.city, address.state, address.post_code, user_address.user_id FROM address JOIN user_address ON user_address.address_id = address.address_id WHERE user_address.user_id", keys, func(r *sql.Rows) error {
+		var key int
+		v := new(Address)
+		if err := r.Scan(append(v.Args(), &key)...); err != nil {
+			return err
+		}
+		res[key] = append(res[key], v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Users returns the rows of user related to this row through user_address.
+func (t *Address) Users(ctx context.Context, q modsql.Querier) ([]*User, error) {
+	rows, err := SelectRelated.QueryContext(ctx, q, 13, t.AddressID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*User
+	for rows.Next() {
+		v := new(User)
+		if err = rows.Scan(v.Args()...); err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, rows.Err()
+}
+
+// LoadUsersForAddresses returns the rows of user related to the given rows through
+// user_address, by the value of address_id.
+func LoadUsersForAddresses(ctx context.Context, q modsql.Querier, rows []*Address) (map[int][]*User, error) {
+	keys := make([]interface{}, len(rows))
+	for i, v := range rows {
+		keys[i] = v.AddressID
+	}
+
+	res := make(map[int][]*User)
+	err := modsql.QueryIn(ctx, q, ENGINE, "SELECT {Q}user{Q}.user_id, {Q}user{Q}.first_name, {Q}user{Q}.last_name, user_address.address_id FROM {Q}user{Q} JOIN user_address ON user_address.user_id = {Q}user{Q}.user_id WHERE user_address.address_id", keys, func(r *sql.Rows) error {
+		var key int
+		v := new(User)
+		if err := r.Scan(append(v.Args(), &key)...); err != nil {
+			return err
+		}
+		res[key] = append(res[key], v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteCascade deletes the row and the rows which depend on it through the
+// foreign keys, within a transaction. It returns the number of rows deleted by
+// table or, if dryRun is true, the number of rows which would be deleted.
+func (t *Account) DeleteCascade(ctx context.Context, q modsql.Querier, dryRun bool) (map[string]int64, error) {
+	return modsql.DeleteCascade(ctx, q, ENGINE, Relations, "account", []string{"acc_num", "acc_type"}, []interface{}{t.AccNum, t.AccType}, dryRun)
+}
+
+// DeleteCascade deletes the row and the rows which depend on it through the
+// foreign keys, within a transaction. It returns the number of rows deleted by
+// table or, if dryRun is true, the number of rows which would be deleted.
+func (t *Catalog) DeleteCascade(ctx context.Context, q modsql.Querier, dryRun bool) (map[string]int64, error) {
+	return modsql.DeleteCascade(ctx, q, ENGINE, Relations, "catalog", []string{"catalog_id"}, []interface{}{t.CatalogID}, dryRun)
+}
+
+// DeleteCascade deletes the row and the rows which depend on it through the
+// foreign keys, within a transaction. It returns the number of rows deleted by
+// table or, if dryRun is true, the number of rows which would be deleted.
+func (t *Book) DeleteCascade(ctx context.Context, q modsql.Querier, dryRun bool) (map[string]int64, error) {
+	return modsql.DeleteCascade(ctx, q, ENGINE, Relations, "book", []string{"book_id"}, []interface{}{t.BookID}, dryRun)
+}
+
+// DeleteCascade deletes the row and the rows which depend on it through the
+// foreign keys, within a transaction. It returns the number of rows deleted by
+// table or, if dryRun is true, the number of rows which would be deleted.
+func (t *User) DeleteCascade(ctx context.Context, q modsql.Querier, dryRun bool) (map[string]int64, error) {
+	return modsql.DeleteCascade(ctx, q, ENGINE, Relations, "user", []string{"user_id"}, []interface{}{t.UserID}, dryRun)
+}
+
+// DeleteCascade deletes the row and the rows which depend on it through the
+// foreign keys, within a transaction. It returns the number of rows deleted by
+// table or, if dryRun is true, the number of rows which would be deleted.
+func (t *Address) DeleteCascade(ctx context.Context, q modsql.Querier, dryRun bool) (map[string]int64, error) {
+	return modsql.DeleteCascade(ctx, q, ENGINE, Relations, "address", []string{"address_id"}, []interface{}{t.AddressID}, dryRun)
+}
+
+// EnumTables has the values of the tables of enumeration.
+var EnumTables = []modsql.EnumTable{
+	{Name: "sex", Values: []modsql.EnumValue{{ID: 0, Name: "female"}, {ID: 1, Name: "male"}}},
+	{Name: "status", Columns: []string{"label", "sort_order", "active"}, Values: []modsql.EnumValue{{ID: 1, Name: "draft", Attrs: []interface{}{"Draft", 2, true}}, {ID: 2, Name: "published", Attrs: []interface{}{"Published", 1, true}}, {ID: 3, Name: "archived", Attrs: []interface{}{"Archived", 3, false}}}},
+}
+
+// SyncEnums makes the tables of enumeration match the Go constants, inserting
+// or updating their rows; the rows which are not in the Go code are reported,
+// but they are not deleted. If verifyOnly is true, it only reports the
+// differences.
+func SyncEnums(ctx context.Context, q modsql.Querier, verifyOnly bool) ([]modsql.EnumReport, error) {
+	return modsql.SyncEnums(ctx, q, ENGINE, EnumTables, verifyOnly)
+}
@@ -0,0 +1,79 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// +build gotask
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jingweno/gotask/tasking"
+	"github.com/kless/modsql"
+	"github.com/kless/modsql/test/model"
+)
+
+// NAME
+//   check-orphans - check the referential integrity of the model into a database
+//
+// SYNOPSIS
+//   check-orphans postgres|mysql|sqlite [data source name]
+//
+// DESCRIPTION
+//
+//   Reports the rows whose foreign keys reference rows which do not exist.
+//   By default, it is used the test database of the engine.
+func TaskCheckOrphans(t *tasking.T) {
+	if len(t.Args) == 0 {
+		t.Fatal("missing engine: postgres|mysql|sqlite")
+	}
+
+	var driver, dsn string
+	var eng modsql.Engine
+
+	switch t.Args[0] {
+	case "postgres":
+		driver, eng = "postgres", modsql.Postgres
+		dsn = fmt.Sprintf("user=%s dbname=%s host=%s sslmode=disable",
+			username, dbname, host.postgres)
+	case "mysql":
+		driver, eng = "mysql", modsql.MySQL
+		dsn = fmt.Sprintf("%s@unix(%s)/%s?parseTime=true", username, host.mysql, dbname)
+	case "sqlite":
+		driver, eng = "sqlite3", modsql.SQLite
+		dsn = dbname + ".db"
+	default:
+		t.Fatalf("unknown engine: %q", t.Args[0])
+	}
+	if len(t.Args) > 1 {
+		dsn = t.Args[1]
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err = checkOrphans(t, db, eng); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// checkOrphans reports the orphan rows of every relation in the model.
+func checkOrphans(t *tasking.T, db *sql.DB, eng modsql.Engine) error {
+	orphans, err := modsql.CheckOrphans(context.Background(), db, eng, model.Relations)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range orphans {
+		t.Error(v)
+	}
+	return nil
+}
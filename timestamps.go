@@ -0,0 +1,158 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"fmt"
+	"log"
+)
+
+// Names of the columns added by Timestamps and SoftDelete.
+const (
+	createdAt = "created_at"
+	updatedAt = "updated_at"
+	deletedAt = "deleted_at"
+)
+
+// _UPDATED_AT_FUNC is the name of the function used by the triggers which set
+// the column "updated_at" in Postgres.
+const _UPDATED_AT_FUNC = "modsql_updated_at"
+
+// Timestamps adds the columns "created_at" and "updated_at", whose value by
+// default is the current time. The column "updated_at" is set at updating the
+// row through the Go code generated, and else through "ON UPDATE" in MySQL and
+// through a trigger in Postgres and SQLite.
+// Both columns are set by the database, so they are not in the insertions.
+func (t *table) Timestamps() {
+	created := Column(createdAt, DateTime)
+	updated := Column(updatedAt, DateTime)
+	created.defaultExpr = "{{.Now}}"
+	updated.defaultExpr = "{{.Now}}"
+
+	t.addAuto("Timestamps", created, updated)
+	t.timestamps = true
+}
+
+// SoftDelete adds the column "deleted_at", which can be NULL, so the rows are
+// marked like deleted instead of being removed. The statements generated to get,
+// update, delete and check a row, and to get the related rows, skip the rows
+// marked like deleted.
+func (t *table) SoftDelete() {
+	deleted := Column(deletedAt, DateTime)
+	deleted.null = true
+
+	t.addAuto("SoftDelete", deleted)
+	t.softDelete = true
+}
+
+// addAuto adds columns whose values are set by the database.
+func (t *table) addAuto(funcName string, col ...*column) {
+	if t.isEnum {
+		log.Fatalf("table %q: %s(): it is a table of enumeration", t.Name, funcName)
+	}
+	if len(t.data) != 0 || len(t.testData) != 0 {
+		log.Fatalf("table %q: %s(): it has to be called before of inserting values",
+			t.Name, funcName)
+	}
+
+	for _, c := range col {
+		if t.column(c.Name) != nil {
+			log.Fatalf("table %q: %s(): column %q already exists", t.Name, funcName, c.Name)
+		}
+		c.auto = true
+		t.Columns = append(t.Columns, *c)
+	}
+}
+
+// insertColumns returns the columns which are set at inserting a row.
+func (t *table) insertColumns() []column {
+	cols := make([]column, 0, len(t.Columns))
+	for _, c := range t.Columns {
+		if !c.auto {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// notDeleted returns the condition to skip the rows marked like deleted,
+// qualified by the table name if qualify is true, or an empty string if the
+// table has not soft delete.
+func (t *table) notDeleted(qualify bool) string {
+	if !t.softDelete {
+		return ""
+	}
+	if qualify {
+		return quoteStatementSQL(t.Name) + "." + deletedAt + " IS NULL"
+	}
+	return deletedAt + " IS NULL"
+}
+
+// and joins the conditions which are not empty.
+func and(cond ...string) string {
+	res := ""
+	for _, v := range cond {
+		if v == "" {
+			continue
+		}
+		if res != "" {
+			res += " AND "
+		}
+		res += v
+	}
+	return res
+}
+
+// sqlColumnExtra returns the clauses to add to the definition of a column
+// added by Timestamps or SoftDelete.
+func (t *table) sqlColumnExtra(c *column) string {
+	switch {
+	case c.null:
+		// MySQL defines the columns of type TIMESTAMP like NOT NULL.
+		return "{{if eq .Engine \"MySQL\"}} NULL{{end}}"
+	case t.timestamps && c.Name == updatedAt:
+		return "{{if eq .Engine \"MySQL\"}} ON UPDATE CURRENT_TIMESTAMP{{end}}"
+	}
+	return ""
+}
+
+// hasTimestamps reports whether some table has the columns of Timestamps.
+func (md *metadata) hasTimestamps() bool {
+	for _, t := range md.tables {
+		if t.timestamps {
+			return true
+		}
+	}
+	return false
+}
+
+// sqlUpdatedAtFunc returns the statement to create the function used by the
+// triggers, only for Postgres.
+func sqlUpdatedAtFunc() string {
+	return fmt.Sprintf("{{if eq .Engine \"Postgres\"}}\nCREATE OR REPLACE FUNCTION %s() "+
+		"RETURNS trigger AS $$ BEGIN NEW.%s = {{.Now}}; RETURN NEW; END; $$ LANGUAGE plpgsql;\n{{end}}",
+		_UPDATED_AT_FUNC, updatedAt)
+}
+
+// sqlDropUpdatedAtFunc returns the statement to drop the function used by the
+// triggers, only for Postgres.
+func sqlDropUpdatedAtFunc() string {
+	return fmt.Sprintf("{{if eq .Engine \"Postgres\"}}\nDROP FUNCTION %s();{{end}}", _UPDATED_AT_FUNC)
+}
+
+// sqlTrigger returns the statement to create the trigger which sets the column
+// "updated_at" at updating a row, in Postgres and SQLite.
+func (t *table) sqlTrigger() string {
+	name := quoteSQL(t.Name + "_" + updatedAt)
+
+	return fmt.Sprintf("{{if eq .Engine \"Postgres\"}}"+
+		"CREATE TRIGGER %[1]s BEFORE UPDATE ON %[2]s FOR EACH ROW EXECUTE PROCEDURE %[3]s();\n"+
+		"{{else if eq .Engine \"SQLite\"}}"+
+		"CREATE TRIGGER %[1]s AFTER UPDATE ON %[2]s FOR EACH ROW WHEN NEW.%[4]s = OLD.%[4]s "+
+		"BEGIN UPDATE %[2]s SET %[4]s = {{.Now}} WHERE rowid = NEW.rowid; END;\n{{end}}",
+		name, t.sqlName, _UPDATED_AT_FUNC, updatedAt)
+}
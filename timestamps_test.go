@@ -0,0 +1,38 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import "testing"
+
+func TestTimestamps(t *testing.T) {
+	resetColumnsErr(t)
+
+	md := Metadata("model", SQLite)
+	tb := Table("note", md,
+		Column("id", Int).PrimaryKey(),
+		Column("body", String),
+	)
+	tb.Timestamps()
+	tb.SoftDelete()
+
+	if n := len(tb.insertColumns()); n != 2 {
+		t.Errorf("got %d columns to insert, want 2", n)
+	}
+	md.genPKForType(0, tb)
+
+	tests := []struct{ got, want string }{
+		{md.sqlSelect[0], `0: "SELECT id, body, created_at, updated_at, deleted_at FROM note WHERE id = {P} AND deleted_at IS NULL"`},
+		{md.sqlUpdate[0], `0: "UPDATE note SET body = {P}, updated_at = {NOW} WHERE id = {P} AND deleted_at IS NULL"`},
+		{md.sqlDelete[0], `0: "UPDATE note SET deleted_at = {NOW} WHERE id = {P} AND deleted_at IS NULL"`},
+		{tb.goType(tb.column("deleted_at")), "sql.NullTime"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %s\nwant %s", tt.got, tt.want)
+		}
+	}
+}
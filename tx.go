@@ -0,0 +1,224 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TxOptions represents the options to run a transaction.
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+
+	// MaxRetries is the maximum number of times that the transaction is run
+	// again after of a serialization failure or a deadlock.
+	// If it is zero, it is used 3; a negative value disables the retries.
+	MaxRetries int
+
+	// MinBackoff and MaxBackoff are the range of time to wait before of
+	// retrying; the time is doubled in every retry, adding a random jitter.
+	// If they are zero or negative, they are used 10 milliseconds and 1 second.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// RunInTx runs fn within a transaction, which is committed if fn returns nil
+// and rolled back otherwise.
+//
+// The transaction is run again when it fails by a serialization failure or a
+// deadlock, according to IsRetryable; so fn could be called several times.
+func RunInTx(ctx context.Context, db *sql.DB, eng Engine, opts *TxOptions, fn func(tx *sql.Tx) error) error {
+	if opts == nil {
+		opts = new(TxOptions)
+	}
+	maxRetries := opts.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = 3
+	}
+	backoff, maxBackoff := opts.MinBackoff, opts.MaxBackoff
+	if backoff <= 0 {
+		backoff = 10 * time.Millisecond
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = time.Second
+	}
+	txOpts := &sql.TxOptions{Isolation: opts.Isolation, ReadOnly: opts.ReadOnly}
+
+	for retry := 0; ; retry++ {
+		err := runTx(ctx, db, txOpts, fn)
+		if err == nil || retry >= maxRetries || !IsRetryable(eng, err) {
+			return err
+		}
+
+		wait := backoff + time.Duration(rand.Int63n(int64(backoff)))
+		if wait > maxBackoff {
+			wait = maxBackoff
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// runTx runs fn within a transaction.
+func runTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		if errRb := tx.Rollback(); errRb != nil && errRb != sql.ErrTxDone {
+			return fmt.Errorf("%w; rollback: %v", err, errRb)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// IsRetryable reports whether the error returned by the driver of the given
+// engine is due to a serialization failure or a deadlock, so the transaction
+// could be run again:
+//
+//	Postgres: SQLSTATE 40001 (serialization_failure) and 40P01 (deadlock_detected)
+//	MySQL:    errors 1213 (deadlock found) and 1205 (lock wait timeout exceeded)
+//	SQLite:   SQLITE_BUSY (5) and SQLITE_LOCKED (6)
+func IsRetryable(eng Engine, err error) bool {
+	if err == nil {
+		return false
+	}
+	code := errorCode(eng, err)
+
+	switch eng {
+	case Postgres:
+		return code == "40001" || code == "40P01"
+	case MySQL:
+		return code == "1213" || code == "1205"
+	case SQLite:
+		if n, errConv := strconv.Atoi(code); errConv == nil {
+			n &= 0xff // primary result code from the extended one
+			return n == 5 || n == 6
+		}
+	}
+	return false
+}
+
+// * * *
+
+// sqlStater is implemented by errors which return the SQLSTATE code, like
+// those ones of the driver "github.com/jackc/pgx".
+type sqlStater interface {
+	SQLState() string
+}
+
+// errorCode returns the code of the error returned by the driver of the given
+// engine: the SQLSTATE in Postgres, and the error number in MySQL and SQLite,
+// using the extended code in SQLite, if any.
+//
+// Since the drivers are not imported, the code is got from the methods or
+// fields used by the most common drivers, or else from the error message.
+func errorCode(eng Engine, err error) string {
+	if eng == Postgres {
+		var e sqlStater
+		if errors.As(err, &e) {
+			return e.SQLState()
+		}
+	}
+
+	fields := []string{"Code"} // github.com/lib/pq
+	switch eng {
+	case MySQL:
+		fields = []string{"Number"} // github.com/go-sql-driver/mysql
+	case SQLite:
+		fields = []string{"ExtendedCode", "Code"} // github.com/mattn/go-sqlite3
+	}
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		v := reflect.ValueOf(e)
+		for v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+
+		if v.Kind() == reflect.Struct {
+			for _, name := range fields {
+				if code := codeString(v.FieldByName(name)); code != "" {
+					return code
+				}
+			}
+		}
+		// modernc.org/sqlite
+		if c, ok := e.(interface{ Code() int }); ok {
+			return strconv.Itoa(c.Code())
+		}
+	}
+
+	return codeFromMessage(eng, err.Error())
+}
+
+// codeString returns the value of a field with an error code as string.
+func codeString(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() != 0 {
+			return strconv.FormatInt(v.Int(), 10)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() != 0 {
+			return strconv.FormatUint(v.Uint(), 10)
+		}
+	}
+	return ""
+}
+
+// codeFromMessage returns the code of an error from its message.
+func codeFromMessage(eng Engine, msg string) string {
+	switch eng {
+	case Postgres:
+		if i := strings.Index(msg, "SQLSTATE "); i != -1 && len(msg) >= i+14 {
+			return msg[i+9 : i+14]
+		}
+	case MySQL:
+		// "Error 1213: ..." or "Error 1213 (40001): ..."
+		if strings.HasPrefix(msg, "Error ") {
+			end := len("Error ")
+			for end < len(msg) && msg[end] >= '0' && msg[end] <= '9' {
+				end++
+			}
+			return msg[len("Error "):end]
+		}
+	case SQLite:
+		switch {
+		case strings.Contains(msg, "database is locked"):
+			return "5"
+		case strings.Contains(msg, "database table is locked"):
+			return "6"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+// Copyright 2013 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package modsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type pqError struct{ Code string }
+
+func (e *pqError) Error() string { return "pq: " + e.Code }
+
+type mysqlError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *mysqlError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+type sqliteError struct {
+	Code         int
+	ExtendedCode int
+}
+
+func (e sqliteError) Error() string { return "sqlite error" }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		eng  Engine
+		err  error
+		want bool
+	}{
+		{Postgres, &pqError{"40001"}, true},
+		{Postgres, fmt.Errorf("exec: %w", &pqError{"40P01"}), true},
+		{Postgres, &pqError{"23505"}, false},
+		{Postgres, errors.New("ERROR: could not serialize access (SQLSTATE 40001)"), true},
+		{MySQL, &mysqlError{1213, "Deadlock found"}, true},
+		{MySQL, errors.New("Error 1205 (HY000): Lock wait timeout exceeded"), true},
+		{MySQL, &mysqlError{1062, "Duplicate entry"}, false},
+		{SQLite, sqliteError{5, 5}, true},
+		{SQLite, sqliteError{6, 262}, true}, // SQLITE_LOCKED_SHAREDCACHE
+		{SQLite, errors.New("database is locked"), true},
+		{SQLite, sqliteError{19, 2067}, false},
+		{SQLite, nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRetryable(tt.eng, tt.err); got != tt.want {
+			t.Errorf("%s: %v: got %v, want %v", tt.eng, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRunInTx(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	calls := 0
+	err := RunInTx(context.Background(), db, Postgres, &TxOptions{MaxRetries: 2},
+		func(tx *sql.Tx) error {
+			calls++
+			return &pqError{"40001"}
+		})
+	if err == nil || calls != 3 {
+		t.Errorf("expected to retry twice, got %d calls and error %v", calls, err)
+	}
+
+	calls = 0
+	errFn := errors.New("failed")
+	err = RunInTx(context.Background(), db, Postgres, nil, func(tx *sql.Tx) error {
+		calls++
+		return errFn
+	})
+	if err != errFn || calls != 1 {
+		t.Errorf("expected to not retry, got %d calls and error %v", calls, err)
+	}
+
+	if err = RunInTx(context.Background(), db, Postgres, nil, func(tx *sql.Tx) error {
+		_, err := tx.Exec("INSERT INTO foo (a) VALUES($1)", 1)
+		return err
+	}); err != nil {
+		t.Error(err)
+	}
+
+	// A negative backoff is replaced by the default one.
+	calls = 0
+	err = RunInTx(context.Background(), db, Postgres,
+		&TxOptions{MaxRetries: 1, MinBackoff: -1, MaxBackoff: -1},
+		func(tx *sql.Tx) error {
+			calls++
+			return &pqError{"40001"}
+		})
+	if err == nil || calls != 2 {
+		t.Errorf("negative backoff: got %d calls and error %v", calls, err)
+	}
+
+	// The error of fn is wrapped when the rollback fails.
+	dbRb, err := sql.Open("modsql_fake", "fail-rollback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dbRb.Close()
+
+	calls = 0
+	err = RunInTx(context.Background(), dbRb, Postgres, &TxOptions{MaxRetries: 1},
+		func(tx *sql.Tx) error {
+			calls++
+			return &pqError{"40001"}
+		})
+	var pqErr *pqError
+	if !errors.As(err, &pqErr) || calls != 2 {
+		t.Errorf("expected to wrap and retry the error of fn, got %d calls and error %v", calls, err)
+	}
+}
@@ -44,6 +44,14 @@ var quoteChar = map[Engine]string{
 	SQLite:   `"`,
 }
 
+// nowSQL are the expressions to get the current time in UTC according to a SQL
+// engine, for the columns of type DateTime.
+var nowSQL = map[Engine]string{
+	MySQL:    "CURRENT_TIMESTAMP",
+	Postgres: "(now() AT TIME ZONE 'UTC')",
+	SQLite:   "CURRENT_TIMESTAMP",
+}
+
 // * * *
 
 // sqlType represents the SQL type.
@@ -70,6 +78,8 @@ const (
 
 	DateTime // time.Time
 	//Duration // time.Duration
+
+	Char // fixed length, 1 by default, padded with blanks; see Size
 )
 
 // goString returns the type corresponding to Go.
@@ -99,7 +109,7 @@ func (t sqlType) goString() string {
 	case Float64:
 		return "float64"
 
-	case String:
+	case String, Char:
 		return "string"
 	case Binary:
 		return "[]byte"
@@ -142,6 +152,8 @@ func (t sqlType) tmplAction() string {
 
 	case String:
 		return "{{.String}}"
+	case Char:
+		return "{{.Char}}"
 	case Binary:
 		return "{{.Binary}}"
 
@@ -185,12 +197,16 @@ type sqlAction struct {
 
 	String      string
 	StringLimit string
+	Varchar     string // to add the size
+	Char        string
 	Binary      string
+	BinaryLimit string
 
 	DateTime string
 	//Duration string
 
-	Q string // character of quote
+	Q   string // character of quote
+	Now string // current time in UTC
 
 	MySQLDrop0   string
 	MySQLDrop1   string
@@ -223,12 +239,16 @@ func getSQLAction(eng Engine) *sqlAction {
 
 			String:      "TEXT",
 			StringLimit: "VARCHAR(255)",
+			Varchar:     "VARCHAR",
+			Char:        "CHAR",
 			Binary:      "BLOB",
+			BinaryLimit: "VARBINARY(255)",
 
 			DateTime: "TIMESTAMP",
 			//Duration: "TIME",
 
-			Q: quoteChar[MySQL],
+			Q:   quoteChar[MySQL],
+			Now: nowSQL[MySQL],
 
 			MySQLDrop0: "\nSET FOREIGN_KEY_CHECKS=0;\n",
 			MySQLDrop1: "\n\nSET FOREIGN_KEY_CHECKS=1;",
@@ -253,12 +273,16 @@ func getSQLAction(eng Engine) *sqlAction {
 
 			String:      "text",
 			StringLimit: "text",
+			Varchar:     "varchar",
+			Char:        "char",
 			Binary:      "bytea",
+			BinaryLimit: "bytea",
 
 			DateTime: "timestamp without time zone",
 			//Duration: "time without time zone",
 
-			Q: quoteChar[Postgres],
+			Q:   quoteChar[Postgres],
+			Now: nowSQL[Postgres],
 
 			PostgresDrop: " CASCADE", // automatically drop objects that depend on the table
 		}
@@ -282,12 +306,16 @@ func getSQLAction(eng Engine) *sqlAction {
 
 			String:      "TEXT",
 			StringLimit: "TEXT",
+			Varchar:     "VARCHAR",
+			Char:        "CHAR",
 			Binary:      "BLOB",
+			BinaryLimit: "BLOB",
 
 			DateTime: "TIMESTAMP",
 			//Duration: "INTEGER",
 
-			Q: quoteChar[SQLite],
+			Q:   quoteChar[SQLite],
+			Now: nowSQL[SQLite],
 		}
 	}
 